@@ -0,0 +1,529 @@
+package main
+
+import (
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	"golang.org/x/exp/slices"
+)
+
+// User changes to the allergen dictionary
+const allergenRulesFile = "allergens.json"
+
+// AllergenRule lists ingredient words that contain an allergen and exceptions that don't, e.g. "flour" but not "rice flour"
+type AllergenRule struct {
+	Keywords   []string `json:"keywords"`
+	Exceptions []string `json:"exceptions"`
+}
+
+// Allergens that can be shown on recipes and excluded from results, in display order
+var allergenNames = []string{"gluten", "dairy", "egg", "nuts", "peanuts", "fish", "shellfish", "soy", "sesame", "celery", "mustard"}
+
+// Meat and honey are not allergens, but are needed to tell if a recipe is vegetarian or vegan
+var defaultAllergenRules = map[string]AllergenRule{
+	"gluten": {
+		Keywords:   []string{"flour", "wheat", "bread", "pasta", "spaghetti", "noodle", "barley", "rye", "semolina", "couscous", "bulgur", "spelt", "seitan", "biscuit", "cracker", "tortilla", "pastry", "dough"},
+		Exceptions: []string{"rice flour", "corn flour", "almond flour", "coconut flour", "buckwheat flour", "chickpea flour", "rice noodle"},
+	},
+	"dairy": {
+		Keywords:   []string{"milk", "butter", "cheese", "cream", "yogurt", "yoghurt", "kefir", "whey", "parmesan", "mozzarella", "ricotta", "mascarpone", "ghee"},
+		Exceptions: []string{"coconut milk", "almond milk", "soy milk", "oat milk", "rice milk", "peanut butter", "cocoa butter", "coconut cream", "cream of tartar"},
+	},
+	"egg": {
+		Keywords:   []string{"egg", "mayonnaise", "meringue"},
+		Exceptions: []string{"eggplant"},
+	},
+	"nuts": {
+		Keywords: []string{"almond", "walnut", "hazelnut", "cashew", "pecan", "pistachio", "macadamia", "pine nut", "brazil nut", "praline", "marzipan"},
+	},
+	"peanuts": {
+		Keywords: []string{"peanut"},
+	},
+	"fish": {
+		Keywords: []string{"fish", "salmon", "tuna", "cod", "anchovy", "anchovies", "sardine", "trout", "mackerel", "haddock"},
+	},
+	"shellfish": {
+		Keywords: []string{"shrimp", "prawn", "crab", "lobster", "mussel", "clam", "oyster", "scallop", "squid", "octopus", "calamari"},
+	},
+	"soy": {
+		Keywords: []string{"soy", "tofu", "tempeh", "edamame", "miso"},
+	},
+	"sesame": {
+		Keywords: []string{"sesame", "tahini"},
+	},
+	"celery": {
+		Keywords: []string{"celery", "celeriac"},
+	},
+	"mustard": {
+		Keywords: []string{"mustard"},
+	},
+	"meat": {
+		Keywords: []string{"meat", "chicken", "beef", "pork", "bacon", "ham", "lamb", "veal", "turkey", "duck", "sausage", "salami", "prosciutto", "mince", "gelatin", "lard"},
+	},
+	"honey": {
+		Keywords: []string{"honey"},
+	},
+}
+
+var allergenRules map[string]AllergenRule
+
+// Compiled word patterns, so they are not compiled again for every ingredient
+var wordRegexps = map[string]*regexp.Regexp{}
+
+// Allergens excluded from all queries
+var excludedAllergens []string
+
+// loadAllergens loads user changes to the allergen dictionary and the excluded allergens
+func loadAllergens() {
+
+	allergenRules = map[string]AllergenRule{}
+	for name, rule := range defaultAllergenRules {
+		allergenRules[name] = rule
+	}
+
+	userRules := map[string]AllergenRule{}
+	loadLocalJSON(allergenRulesFile, &userRules)
+
+	for name, rule := range userRules {
+		allergenRules[name] = rule
+	}
+
+	excludedAllergens = []string{}
+	for _, name := range strings.Split(mainApp.Preferences().String("excludedAllergens"), ",") {
+		if len(name) != 0 {
+			excludedAllergens = append(excludedAllergens, name)
+		}
+	}
+}
+
+// wordPattern matches any of the words as whole words, like ingredient searches do.
+// Ingredient names are matched locally after their accents are folded, so the words are folded too.
+func wordPattern(words []string) string {
+
+	quoted := []string{}
+	for _, word := range words {
+		quoted = append(quoted, regexp.QuoteMeta(strings.ToLower(foldAccents(word))))
+	}
+
+	return wholeWordsPattern(quoted)
+}
+
+// databaseWordPattern matches the words like wordPattern in ingredient names that are not folded,
+// so "creme" also matches "crème" in the database
+func databaseWordPattern(words []string) string {
+
+	accentPatterns := []string{}
+	for _, word := range words {
+		accentPatterns = append(accentPatterns, accentInsensitive(strings.ToLower(foldAccents(word))))
+	}
+
+	return wholeWordsPattern(accentPatterns)
+}
+
+// Letters with diacritics by the ASCII letter they are folded to
+var accentedLetters = func() map[rune]string {
+
+	letters := map[rune]string{}
+
+	for letter := rune(0xC0); letter <= 0x17F; letter++ {
+
+		folded := []rune(foldAccents(string(letter)))
+
+		if len(folded) == 1 && folded[0] < unicode.MaxASCII && folded[0] != letter {
+			base := unicode.ToLower(folded[0])
+			letters[base] += string(letter)
+		}
+	}
+
+	return letters
+}()
+
+// accentInsensitive returns a regular expression matching a folded word with or without diacritics
+func accentInsensitive(word string) string {
+
+	var pattern strings.Builder
+
+	for _, letter := range word {
+
+		if variants, exists := accentedLetters[letter]; exists {
+			pattern.WriteString("[" + string(letter) + variants + "]")
+			continue
+		}
+
+		pattern.WriteString(regexp.QuoteMeta(string(letter)))
+	}
+
+	return pattern.String()
+}
+
+// ingredientContains checks if an ingredient name matches an allergen rule
+func ingredientContains(ingredientName string, rule AllergenRule) bool {
+
+	if len(rule.Keywords) == 0 {
+		return false
+	}
+
+	name := strings.ToLower(foldAccents(ingredientName))
+
+	if len(rule.Exceptions) != 0 && wordRegexp(rule.Exceptions).MatchString(name) {
+		return false
+	}
+
+	return wordRegexp(rule.Keywords).MatchString(name)
+}
+
+func wordRegexp(words []string) *regexp.Regexp {
+
+	pattern := wordPattern(words)
+
+	if _, exists := wordRegexps[pattern]; !exists {
+		wordRegexps[pattern] = regexp.MustCompile(pattern)
+	}
+
+	return wordRegexps[pattern]
+}
+
+// recipeContains checks if any ingredient of a recipe matches the named rule
+func recipeContains(recipe Recipe, ruleName string) bool {
+
+	rule := allergenRules[ruleName]
+
+	// Ingredients of referenced recipes count too
+	for _, ingr := range expandIngredients(recipe.Ingredients) {
+		if ingredientContains(ingr.Name, rule) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// recipeAllergens returns all allergens detected in a recipe
+func recipeAllergens(recipe Recipe) []string {
+
+	found := []string{}
+	for _, name := range allergenNames {
+		if recipeContains(recipe, name) {
+			found = append(found, name)
+		}
+	}
+
+	return found
+}
+
+// recipeDiets returns "Vegetarian" or "Vegan" for recipes without animal products
+func recipeDiets(recipe Recipe) []string {
+
+	if len(recipe.Ingredients) == 0 {
+		return []string{}
+	}
+
+	for _, name := range []string{"meat", "fish", "shellfish"} {
+		if recipeContains(recipe, name) {
+			return []string{}
+		}
+	}
+
+	for _, name := range []string{"dairy", "egg", "honey"} {
+		if recipeContains(recipe, name) {
+			return []string{"Vegetarian"}
+		}
+	}
+
+	return []string{"Vegetarian", "Vegan"}
+}
+
+// allergenSummary returns a short text with allergens and diets of a recipe, used in result rows
+func allergenSummary(recipe Recipe) string {
+
+	parts := trList(recipeDiets(recipe))
+
+	if allergens := recipeAllergens(recipe); len(allergens) != 0 {
+		parts = append(parts, tr("Contains: ")+strings.Join(trList(allergens), ", "))
+	}
+
+	return strings.Join(parts, " | ")
+}
+
+// allergenExclusionFilters returns MongoDB conditions that leave out recipes with excluded allergens.
+// Exceptions are handled with a negative lookahead, so "rice flour" is not matched by "flour".
+func allergenExclusionFilters() []map[string]interface{} {
+
+	filters := []map[string]interface{}{}
+
+	for _, name := range excludedAllergens {
+
+		rule := allergenRules[name]
+
+		if len(rule.Keywords) == 0 {
+			continue
+		}
+
+		pattern := databaseWordPattern(rule.Keywords)
+		if len(rule.Exceptions) != 0 {
+			pattern = `^(?!.*` + databaseWordPattern(rule.Exceptions) + `).*` + pattern
+		}
+
+		filters = append(filters, map[string]interface{}{
+			"ingredients.name": map[string]interface{}{
+				"$not": map[string]string{"$regex": pattern, "$options": "i"},
+			},
+		})
+	}
+
+	// The database cannot look into referenced recipes, so recipes that contain allergens through them are excluded by ID
+	if excludedIds := recipesWithReferencedAllergens(); len(excludedIds) != 0 {
+		filters = append(filters, map[string]interface{}{
+			"_id": map[string]interface{}{"$nin": excludedIds},
+		})
+	}
+
+	return filters
+}
+
+// recipesWithReferencedAllergens returns object IDs of indexed recipes that reference other recipes and contain an excluded allergen,
+// the same way their badges are found
+func recipesWithReferencedAllergens() []map[string]string {
+
+	objectIds := []map[string]string{}
+
+	if localIndex == nil || len(excludedAllergens) == 0 {
+		return objectIds
+	}
+
+	for _, recipe := range localIndex.recipes {
+
+		hasReference := slices.ContainsFunc(recipe.Ingredients, func(ingr Ingredient) bool { return len(ingr.RecipeId) != 0 })
+
+		if hasReference && len(withoutExcludedAllergens([]Recipe{recipe})) == 0 {
+			objectIds = append(objectIds, map[string]string{"$oid": recipe.Id})
+		}
+	}
+
+	return objectIds
+}
+
+// withAllergenExclusions combines a query filter with the global allergen exclusions
+func withAllergenExclusions(filter map[string]interface{}) map[string]interface{} {
+
+	exclusions := allergenExclusionFilters()
+
+	if len(exclusions) == 0 {
+		return filter
+	}
+
+	conditions := []map[string]interface{}{filter}
+	conditions = append(conditions, exclusions...)
+
+	return map[string]interface{}{"$and": conditions}
+}
+
+// withoutExcludedAllergens removes recipes with excluded allergens from locally filtered results
+func withoutExcludedAllergens(recipes []Recipe) []Recipe {
+
+	if len(excludedAllergens) == 0 {
+		return recipes
+	}
+
+	allowed := []Recipe{}
+
+	for _, recipe := range recipes {
+
+		excluded := false
+		for _, name := range excludedAllergens {
+			if recipeContains(recipe, name) {
+				excluded = true
+				break
+			}
+		}
+
+		if !excluded {
+			allowed = append(allowed, recipe)
+		}
+	}
+
+	return allowed
+}
+
+// Badge shows a text on a background in the primary colour of the current theme
+type Badge struct {
+	widget.BaseWidget
+	Text string
+}
+
+func newBadge(text string) *Badge {
+
+	badge := &Badge{Text: text}
+	badge.ExtendBaseWidget(badge)
+
+	return badge
+}
+
+func (b *Badge) CreateRenderer() fyne.WidgetRenderer {
+
+	background := canvas.NewRectangle(theme.PrimaryColor())
+	label := widget.NewLabelWithStyle(b.Text, fyne.TextAlignCenter, fyne.TextStyle{Bold: true})
+
+	return &badgeRenderer{background: background, label: label, content: container.NewMax(background, label)}
+}
+
+type badgeRenderer struct {
+	background *canvas.Rectangle
+	label      *widget.Label
+	content    *fyne.Container
+}
+
+func (r *badgeRenderer) Layout(size fyne.Size) {
+	r.content.Resize(size)
+}
+
+func (r *badgeRenderer) MinSize() fyne.Size {
+	return r.content.MinSize()
+}
+
+// Refresh is also called when the theme changes, so the background takes the new primary colour
+func (r *badgeRenderer) Refresh() {
+	r.background.FillColor = theme.PrimaryColor()
+	r.background.Refresh()
+	r.label.Refresh()
+}
+
+func (r *badgeRenderer) Objects() []fyne.CanvasObject {
+	return []fyne.CanvasObject{r.content}
+}
+
+func (r *badgeRenderer) Destroy() {}
+
+// createAllergenBadges shows diets and allergens of a recipe as badges
+func createAllergenBadges(recipe Recipe) fyne.CanvasObject {
+
+	badges := container.NewHBox()
+
+	for _, diet := range recipeDiets(recipe) {
+		badges.Add(newBadge(tr(diet)))
+	}
+
+	for _, allergen := range recipeAllergens(recipe) {
+		badges.Add(newBadge(tr(allergen)))
+	}
+
+	return container.NewHScroll(badges)
+}
+
+// refreshCurrentResults runs the current query again from the first page, e.g. after filters change
+func refreshCurrentResults() {
+
+	if len(currentQuery["type"]) == 0 {
+		return
+	}
+
+	currentRecipes, currentCount = getCurrentResults(0)
+
+	allPages := int(math.Ceil(float64(currentCount) / float64(config.resultsPerPage)))
+	currentPage = 1
+	displayResults(allPages, currentResultsTitle)
+}
+
+// showAllergenFilterDialog lets the user choose allergens that are excluded from all results
+func showAllergenFilterDialog() {
+
+	checks := container.NewGridWithColumns(2)
+	selected := map[string]*widget.Check{}
+
+	for _, name := range allergenNames {
+		check := widget.NewCheck(tr(name), nil)
+		check.SetChecked(slices.Contains(excludedAllergens, name))
+		selected[name] = check
+		checks.Add(check)
+	}
+
+	editButton := widget.NewButtonWithIcon(tr("Edit dictionary"), theme.DocumentCreateIcon(), func() { showAllergenDictionaryDialog() })
+
+	content := container.NewVBox(widget.NewLabel(tr("Hide recipes containing:")), checks, editButton)
+
+	dialog.ShowCustomConfirm(tr("Allergens"), tr("Apply"), tr("Cancel"), content, func(confirmed bool) {
+
+		if !confirmed {
+			return
+		}
+
+		excludedAllergens = []string{}
+		for _, name := range allergenNames {
+			if selected[name].Checked {
+				excludedAllergens = append(excludedAllergens, name)
+			}
+		}
+
+		mainApp.Preferences().SetString("excludedAllergens", strings.Join(excludedAllergens, ","))
+		refreshCurrentResults()
+
+	}, mainWindow)
+}
+
+// showAllergenDictionaryDialog allows editing the ingredient words of each allergen
+func showAllergenDictionaryDialog() {
+
+	ruleNames := []string{}
+	for name := range allergenRules {
+		ruleNames = append(ruleNames, name)
+	}
+	sort.Strings(ruleNames)
+
+	keywordEntry := widget.NewMultiLineEntry()
+	keywordEntry.SetPlaceHolder(tr("Ingredient words, separated by commas"))
+	keywordEntry.Wrapping = fyne.TextWrapWord
+
+	exceptionEntry := widget.NewMultiLineEntry()
+	exceptionEntry.SetPlaceHolder(tr("Exceptions, separated by commas"))
+	exceptionEntry.Wrapping = fyne.TextWrapWord
+
+	// Rules are shown with translated names, but stored with English ones
+	ruleNamesByLabel := map[string]string{}
+	for _, name := range ruleNames {
+		ruleNamesByLabel[tr(name)] = name
+	}
+
+	ruleSelect := widget.NewSelect(trList(ruleNames), func(label string) {
+		name := ruleNamesByLabel[label]
+		keywordEntry.SetText(strings.Join(allergenRules[name].Keywords, ", "))
+		exceptionEntry.SetText(strings.Join(allergenRules[name].Exceptions, ", "))
+	})
+	ruleSelect.SetSelectedIndex(0)
+
+	splitWords := func(text string) []string {
+		words := []string{}
+		for _, word := range strings.Split(text, ",") {
+			if word = strings.ToLower(strings.TrimSpace(word)); len(word) != 0 {
+				words = append(words, word)
+			}
+		}
+		return words
+	}
+
+	saveButton := widget.NewButtonWithIcon(tr("Save"), theme.DocumentSaveIcon(), func() {
+
+		allergenRules[ruleNamesByLabel[ruleSelect.Selected]] = AllergenRule{
+			Keywords:   splitWords(keywordEntry.Text),
+			Exceptions: splitWords(exceptionEntry.Text),
+		}
+
+		if err := saveLocalJSON(allergenRulesFile, allergenRules); err != nil {
+			dialog.NewError(err, mainWindow).Show()
+		}
+	})
+
+	content := container.NewVBox(ruleSelect, widget.NewLabel(tr("Contained in:")), keywordEntry, widget.NewLabel(tr("Except:")), exceptionEntry, container.NewHBox(saveButton))
+
+	dictionaryDialog := dialog.NewCustom(tr("Allergen dictionary"), tr("Close"), content, mainWindow)
+	dictionaryDialog.Resize(fyne.NewSize(500, 450))
+	dictionaryDialog.Show()
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestIngredientContains(t *testing.T) {
+
+	tests := []struct {
+		ingredient string
+		rule       string
+		contains   bool
+	}{
+		{"butter", "dairy", true},
+		{"butternut squash", "dairy", false},
+		{"peanut butter", "dairy", false},
+		{"Crème fraîche, or sour cream", "dairy", true},
+		{"minced garlic", "meat", false},
+		{"hamburger bun", "meat", false},
+		{"smoked ham", "meat", true},
+		{"2 eggs", "egg", true},
+		{"eggplant", "egg", false},
+		{"rice flour", "gluten", false},
+		{"plain flour", "gluten", true},
+		{"noodles", "gluten", true},
+		{"pine nuts", "nuts", true},
+	}
+
+	for _, test := range tests {
+		if contains := ingredientContains(test.ingredient, defaultAllergenRules[test.rule]); contains != test.contains {
+			t.Errorf("ingredientContains(%q, %s) = %v, want %v", test.ingredient, test.rule, contains, test.contains)
+		}
+	}
+}
+
+func TestDatabaseWordPattern(t *testing.T) {
+
+	tests := []struct {
+		words      []string
+		ingredient string
+		matches    bool
+	}{
+		{[]string{"creme"}, "Crème fraîche", true},
+		{[]string{"crème"}, "creme fraiche", true},
+		{[]string{"jajce"}, "2 JAJCI", false},
+		{[]string{"cebula"}, "rdeča čebula", true},
+		{[]string{"butter"}, "Butternut squash", false},
+		{[]string{"egg"}, "Eggs", true},
+	}
+
+	for _, test := range tests {
+
+		// The database matches case insensitively
+		pattern := regexp.MustCompile("(?i)" + databaseWordPattern(test.words))
+
+		if matches := pattern.MatchString(test.ingredient); matches != test.matches {
+			t.Errorf("databaseWordPattern(%v) matches %q = %v, want %v", test.words, test.ingredient, matches, test.matches)
+		}
+	}
+}
@@ -0,0 +1,142 @@
+package main
+
+import (
+	"image/color"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+// Themes users can choose from; the system theme follows the light or dark mode of the device
+const (
+	themeDark   = "dark"
+	themeLight  = "light"
+	themeSystem = "system"
+)
+
+var themeNames = map[string]string{
+	themeDark:   "Dark",
+	themeLight:  "Light",
+	themeSystem: "System",
+}
+
+// MealTime accent colours for dark and light variants
+var (
+	accentDark  = color.NRGBA{R: 0xFF, G: 0x8A, B: 0x50, A: 0xFF}
+	accentLight = color.NRGBA{R: 0xD8, G: 0x4A, B: 0x1B, A: 0xFF}
+)
+
+// MealTimeTheme is the default Fyne theme with MealTime accent colours
+type MealTimeTheme struct {
+	// Variant used instead of the system one, unless the theme follows the system
+	variant      fyne.ThemeVariant
+	followSystem bool
+}
+
+func newMealTimeTheme(themeName string) *MealTimeTheme {
+
+	switch themeName {
+	case themeLight:
+		return &MealTimeTheme{variant: theme.VariantLight}
+
+	case themeSystem:
+		return &MealTimeTheme{followSystem: true}
+
+	default:
+		return &MealTimeTheme{variant: theme.VariantDark}
+	}
+}
+
+func (t *MealTimeTheme) Color(name fyne.ThemeColorName, variant fyne.ThemeVariant) color.Color {
+
+	if !t.followSystem {
+		variant = t.variant
+	}
+
+	accent := accentDark
+	if variant == theme.VariantLight {
+		accent = accentLight
+	}
+
+	switch name {
+	case theme.ColorNamePrimary:
+		return accent
+
+	case theme.ColorNameFocus:
+		return color.NRGBA{R: accent.R, G: accent.G, B: accent.B, A: 0x7F}
+
+	case theme.ColorNameSelection:
+		return color.NRGBA{R: accent.R, G: accent.G, B: accent.B, A: 0x3F}
+	}
+
+	return theme.DefaultTheme().Color(name, variant)
+}
+
+func (t *MealTimeTheme) Font(style fyne.TextStyle) fyne.Resource {
+	return theme.DefaultTheme().Font(style)
+}
+
+func (t *MealTimeTheme) Icon(name fyne.ThemeIconName) fyne.Resource {
+	return theme.DefaultTheme().Icon(name)
+}
+
+func (t *MealTimeTheme) Size(name fyne.ThemeSizeName) float32 {
+	return theme.DefaultTheme().Size(name)
+}
+
+func applyTheme() {
+	mainApp.Settings().SetTheme(newMealTimeTheme(config.theme))
+}
+
+// Heading is a large text in the text colour of the current theme
+type Heading struct {
+	widget.BaseWidget
+	Text     string
+	TextSize float32
+}
+
+// newHeading creates a heading that keeps the text colour of the current theme when the theme changes
+func newHeading(text string, size float32) *Heading {
+
+	heading := &Heading{Text: text, TextSize: size}
+	heading.ExtendBaseWidget(heading)
+
+	return heading
+}
+
+func (h *Heading) CreateRenderer() fyne.WidgetRenderer {
+
+	text := canvas.NewText(h.Text, theme.ForegroundColor())
+	text.TextSize = h.TextSize
+
+	return &headingRenderer{heading: h, text: text}
+}
+
+type headingRenderer struct {
+	heading *Heading
+	text    *canvas.Text
+}
+
+func (r *headingRenderer) Layout(size fyne.Size) {
+	r.text.Resize(size)
+}
+
+func (r *headingRenderer) MinSize() fyne.Size {
+	return r.text.MinSize()
+}
+
+// Refresh is also called when the theme changes, so the text takes the new text colour
+func (r *headingRenderer) Refresh() {
+	r.text.Text = r.heading.Text
+	r.text.TextSize = r.heading.TextSize
+	r.text.Color = theme.ForegroundColor()
+	r.text.Refresh()
+}
+
+func (r *headingRenderer) Objects() []fyne.CanvasObject {
+	return []fyne.CanvasObject{r.text}
+}
+
+func (r *headingRenderer) Destroy() {}
@@ -10,3 +10,8 @@ var resourcePlaceholderJpg = &fyne.StaticResource{
 	StaticContent: []byte(
 		"\xff\xd8\xff\xe0\x00\x10JFIF\x00\x01\x01\x00\x00\x01\x00\x01\x00\x00\xff\xdb\x00C\x00\x03\x02\x02\x02\x02\x02\x03\x02\x02\x02\x03\x03\x03\x03\x04\x06\x04\x04\x04\x04\x04\b\x06\x06\x05\x06\t\b\n\n\t\b\t\t\n\f\x0f\f\n\v\x0e\v\t\t\r\x11\r\x0e\x0f\x10\x10\x11\x10\n\f\x12\x13\x12\x10\x13\x0f\x10\x10\x10\xff\xdb\x00C\x01\x03\x03\x03\x04\x03\x04\b\x04\x04\b\x10\v\t\v\x10\x10\x10\x10\x10\x10\x10\x10\x10\x10\x10\x10\x10\x10\x10\x10\x10\x10\x10\x10\x10\x10\x10\x10\x10\x10\x10\x10\x10\x10\x10\x10\x10\x10\x10\x10\x10\x10\x10\x10\x10\x10\x10\x10\x10\x10\x10\x10\x10\x10\xff\xc2\x00\x11\b\x01\x90\x01\xf4\x03\x01\"\x00\x02\x11\x01\x03\x11\x01\xff\xc4\x00\x1b\x00\x01\x01\x00\x03\x01\x01\x01\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x01\x02\x05\x06\x04\x03\a\xff\xc4\x00\x14\x01\x01\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\xff\xda\x00\f\x03\x01\x00\x02\x10\x03\x10\x00\x00\x01\xfd\x88\x12\x80\x11@\x10\xa4\xa0\x00\x00%\tD\xa0\x00\x00\x01(\x00\x00\t@\t`\xa0\x94J\x00\x00\x00\x11@\x00\x00\x00\x04\xa0\x00\x00\x00\ta@\x00\x00\x00\x00\x00\x00\x00\x02\x14\x02\x14\x00\x00\x00\x10\x13 \x94\x00\x8a\"\x89e\x00\x01(J\x12\x88\xb0P\x03\x1c\x80\x00\x00\x00\x069\x069\x00\x12\xc0\xa2,\x14\x00J\x12\x80 (%\x00\x12\x84\xa2P\x00\x00\x00J\x12\x80\x00\x94\x00\x02P\x00\x00\x12\x80\x12\u0082(\x00\x00\x00\x02\x02\x80\x00\"\x84\xa2P\x00\x00\x00c\x90J\x00\x00\x00\x00\x00\x00\x00c\x90\x01(\x94\"\x80\x00\x00\x00 (%\x00 (!@\x00c\x90\x01,,\xb0P\x02P\xc7 \x02T(\x00\x01\x8e@\x04\xb0YD\xb0P\x00\x00\x00\x80\xa0\x00\x02\n\te\x00\x00\x00\x04,\xb0\xa0\x00\x02l\x0f\x06]6\xe8\xe5w{Hr\x9aN\xe3\x86(\x00\x00\x04\xb0\xa9D\xb0\xa0\x00\x00\x00\x80\xa0\x00\b\n\te\x00\x00\x00\x18\xe5\v,(\r\x9e\xe8\xe5\xb7]6G\x87\xdc\xf0\x9e\xec9\x9d1\xd4{\xf8=\xf1\xd4\xf0\xbdր\xe6\x00\x02P\x02X,\xa2XP\x00\x00\x00@P\x00\x01\x05\x04\xb0T\xa0\x00\x00e\xb54\xfe\x9e\xaffs\x9b\xafT\rf\x8c\xea\xf4\x9c\xcc=\xde\n\x00}\xbe\x03\xf4\\5\x9b\x93\xf3\x99\xb8Ӏ\bP%\x85J%\x85J\x00\x00\x00@P\x00\x00\x85\x01(\x00\xfa\x1f9\xbdݜ\xae\xeb\x7fO\x8f\xd7\x0f\x91\xe8\xc7!\xa7\xd2\xf6p\xe1'z8'x87z8'z8'z9n\xa2\x8dg-ݎ\r\xdeÃÿ\xd6\x1c`\x04(\x04(\x00\x00\x00 (\x00\x00\x00%\x94\x01\xbe\xd0\xfdO\xd0X\xfc\xcf6\x93EO\xa7I\xcb\xf5\x06\xfb\xc5\xeeᎦq\x03\xb7q\x03\xb7q\x03\xb8p㸜@\xee'\x10;\x87\x0e;w\x10;{\xc3\xfd\x8f\xd0u{]Y\xc6\x01(\x01,(\x00\x00\x00 (\t@\b)\x05\x82\xa5\x12\x8e\xbfo\xc6vg\x1d\xaa\xeb\xf8\xf2\xf5\x1c\xbfPo\xb8~\xe3\x87<)\xf7>_~\xd3\xd6~oz\xee@\xa9@\t\x99\x8aP\a\xdb\xe3\xf5?A\xd5\xed5g\x1a\x94K\n\x94@\xa9@\x00\x00\x94\x80\x99\x06,\x869\x04\x05\xc7(%\xa4\xa1\x8e@\xef\xf8\x0e\x8c\xe88/\xd09s\x9f\xea\xb9~\xa0\xdfp\xfd\xc7\x0ek\xf7\xbaOq\xdc1\xc8p\x1d\x9f\nc\x90ch\xfb\xf5yl\x8f\xce.\xcb\\`\xfabc\xf7\xf9}O\xd0u{MYŲ\t`)\x8d\tTc\x90J1\xc81d &A\x8e@\x04P\xc7!%\xa4\xa0\x94=^X~\x8d\xe4\xf9lO\xce:\x9d6\xe4\xdfp\xfd\xc7\x0ek\xf2\x0ff\xc3F>\x9f\x1c\x869\x03\x7f\xaa\xeeL\xc1\xf0\xcf\xe89]\x1fe\xc6\x13\xef\xf2\xfb\x1f\xa0궚\xb3\x8d\x02X&C\x1ba2\x00\x12\x801\xc8 (\x00\x01\x02\x82Y@\x00\x03s\xd6\xfeyߚi\xbaԛ\xae\x1f\xb8\xe1\xcf\b\aܾ\x9e\xaf\xd2~s\x97K\x81\xb4\xf7\x80\x00a\xc0~\x85\xc8\x1a\x8f\xb7\xc7\xea~\x83\xab\xdaj\xce4\taR\x89a@\x00\x00\x01\x01@\x00\x04\x14\x85A@\x00\x0e\xab\x95\xf7\x9d\xbf\xcf\xe8\x1c?qÞ\x14\xc8˴\x9b\x00\x00\x00\x00\x06\x93w\xf1??\xfb|~\xa7\xe8:\xbd\xa6\xac\xe3@\x96\x15(AR\x80\x00\x00\x10\x14\x00\x01\x14\x01(\x00\x00\tGu\xec\xe6:q\xc3\xf7\x1c\x81\xa8\xf4f=\xcf\b\xf6\xbcC\xdc\xf0\x8fk\xc4=\xaf\x10\xf6\xdf\b\xf6\xdf\b\xf3g\xf6\xfa\x9d\xb6\xafi\xab8\xd0%\x12\x82P\x00\x00\x00\x04\x05\x04\xb2\x80@PK\x02\x80\tBQ\xf6\xef\xbf:莕\xa6\x1b\x96\x98nZa\xb9i\x86\xe5\xa6\x1b\x96\x98nZa\xb9i\x86\xe5\xa6\x1b\x96\x98nu\x7f/\t\xa0\x02X,\xa2XT\xa0\x00\x00\x04\x05\x00\x00\bPK(J\x00\x00\x02\x16XP\x00\x00\x00\x00\x00\x00\x00\x02XP%\x85\x00\x00\x00\x04\x05\x01\x8e@\x10\x86@\x97\x1c\x80\x00\x00\x04\xb8\x99K\x89\x90\x001\xc8\x00\x00\x00\x00\x01\x89\x90\x12\xc1e\x12\xe2d\x00\x00\x00\b\n\x00\x00\x00\x00\x00\x00\x00\x00J\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\b\n\x94%\x00 \xa4*P\x94\x00B\x90\xa4*\n\x94\x00\x94%\x04(\x00%\x04)\n\x01\n\x90\xc9\x05J\x00!@\x01\x05J\x10T\xa2\x05@\xb0T\xa1(AHX\x16\x05APT\x15\x05APT\x15\x05AHT\x16XT\x16\x05AHR\x15(@\x05J\x12\x84\xa1\x05 \xb8ҥ\tA\nB\xa0\xb1\f\x92\x80\x12\x84\xa1\x05A@APR\x14\x85!R\x88\x86IA\nB\xa5\tH\x05\x94Ƃ\xca@&P\x01e!Lm\x85\xc7(\x16\x00\x00Q\x00\xb2\x90\x00\n@\x01q\xca\x00Y`\x94J\x00Yae\x84\xaacT\x80\xff\xc4\x00(\x10\x00\x01\x04\x01\x03\x04\x03\x01\x00\x03\x01\x00\x00\x00\x00\x00\x03\x00\x01\x02\x04\x05\x14\x154\x10\x133P\x11\x12 0\"@\x90A\xff\xda\x00\b\x01\x01\x00\x01\x05\x02\xff\x00\xa2\xb1\x8c\xa6\xe2Şh8\xfa\xe1YP\xb4\t\xee?\xf44l\x99\v\x14(\xa8B\x10n\x97\x05ݮ\xde\xd8b)Pq2w\r:\xe1\xeb)F\f|\x98 \xab\x1d\xac\x05\\\x13\x86ǲo\x998q\xd6\n\x83\x8c\x04\x1337S\\\xae\x04l\xac\xdd\xe6B\x17\xa6(\xae\xd3YP\xfc\x8f\xd8\n\xb5\x83 \xe2b\x85\\!\xeb2@l\\\xa8\xe2\x8dvɿ!+\x84\xac\xed&(أx\xbc%\xeb#\x19MÌ<\xd0q\xf5\x85\xf8.B\xb0\x91\xb2\x86\x9a\x94\xa57\xfd\xe3\v\xf7\xac\xb2\x81\xfa\x1f\xd4\xfc\xa0Ҳd,P\xe2\xa01\x89\xba;\xfc1\xadZE\x8eH\xcbGih\xed\xad\x1d\xb5\xa3\xb6\xb4v֎\xda\xd1\xdbZ;kGmh\xed\xacx\xac\x82¾\a=}%\xb5\xa3\xb6\xb4v֎ژ\x0e&\xf4C\x19\v \xe2\x89$\x1aU\xc1\xd6R\x8c\x18F\x19\xbf\xd6\xc9p\xfd\x16$\xbf\xe5\xd0\xd7+\x85\x13,I)\x90\x85X\x8f\x02\x9d\xcaÖ\xbe\xa2\xd7\xd3Z\xfa\x8b_Mk\xe9\xad}E\xaf\xa8\xb5\xf5\x16\xbe\xa2\xd7\xd3Z\xfa\x8b_Qk\xea-}E\xaf\xa6\xb5\xf4־\xa2k\xb5]\xd6K\x87\xe8\x82N\xd1b\xed&,>\xe3\xf8\xf8~\x98\x8f\x02\xbf\xcc\xfe\xbf\x1f\x80\xf9\xd6K\x87\xe8\xf1\xa6\xeeWY!v\xact\xc4x\x15\xfegFiI\u07bd\x88\xb7\xf5\x0f\x99d\xb8~\x8f\x1an݅\x92\v\x90\x1d1\x1e\x05\x7f\x98\x82\x19X(+\x8e\xbc\x15\xdaP4?O\x193~C\xe7Y.\x1f\xa3g\xfa\xb8I\xde\x13\xb3I\x8c>ɖ#\xc0\xaf\xf3\x16'\xcf\xd6\xc4Z'\xfcW\f\xac\x16\xcdHί\xe4>u\x92\xe1\xfaLI\x7f\xc5e\x83\xf5\x9a\xc4x\x15\xfeb\xa6}9\xfe~YY\xb1\x1a\xe3ww\x7f\xc5*\xbaa\xac\x80;6\"қ\xca2\x84\xba\aβ\\?IT\xbd\x9b\nػ\xd5\xd9b<\n\xff\x003\xa0-\x9e\xba|\xb1~\bR\x1a_\x8ce_\x97\xe8A\f\xcd\x01\xc2\r\x96\x1f\xc1:\aβ\\?K@\xbd\xda\xca\xf0{6q\x1e\x05\x7f\x99\xfc+W\x95\x92\xc61\x84\x7f\x19\x11\xf7*\xf4\x0f\x99d\xb8~\x97\x16o\xa1\x96T_`\xe2<\n\xff\x003\xf0\x00J\xc1l\xd2-wL\xce\xefN\xb3V\x17\xe6L\xd2bA\xc6D\x1f2\xc9p\xfd,&\xe3\x9c&Ą\xe0Ć.\x0e1\xab\xfcΡ\t,\x12\xbdq\xd6\x1a\xb5\x8ci,u)B_\xbc\xa0\xde6P|\xeb%\xc3\xf4س<\u009a\x11gW\xf9\x9d!\t\x96u*ƨ\xff\x00\x9eT\x7fj\xe8>e\x92\xe1\xfaj&p\xd9\xeb\x7f\x98\x99\x9dޥHV\x87\xf4,\x18\xa3\xf8\xfa\xb8|\xeb%\xc3\xf4\xf5I\xde\aK\xfc\xc4\x03ʼ\xf7[+u\xb2\xb7k+u\xb2\xb7[+u\xb2\xb7k\vu\xb2\xb7k\vu\xb2\xb7k\vu\xb2\xb7k\vu\xb2\xa5'\x9c\x83\xe7Y.\x1f\xa7ę\x99\xfa\\\xadbv\xb4\x96֒\xda\xd2[ZKkImi-\xad%\xb5\xa4\xb6\xb4\x96֒\xda\xd2[ZKkImi-\xad%\xb5\xa4\xb6\xb4\x96Ъ\xd9b\xac\x97\x0fӀ\x9d\x933\xb4\x9b\xfd\\\x97\x0f\xd4U\xc9\ba\xddj\xad֪\xddj\xad֪\xddj\xad֪\xddj\xad֪\xddj\xad֪\xddj\xad֪\xddj\xad֪\xddj\xad֪\xddj\xad֪\xddj\xad֪\xddj\xab\x97\xc0p\x7f\xd8_\xff\xc4\x00\x14\x11\x01\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x90\xff\xda\x00\b\x01\x03\x01\x01?\x01H?\xff\xc4\x00\x14\x11\x01\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x90\xff\xda\x00\b\x01\x02\x01\x01?\x01H?\xff\xc4\x006\x10\x00\x01\x02\x03\x02\r\x03\x02\x06\x03\x00\x00\x00\x00\x00\x00\x01\x00\x02\x03\x10\x11\x12! 1234ABPQar\x81\x91\"#q0R\x04\x13@\x90\xa1\xb1\x14$`\xff\xda\x00\b\x01\x01\x00\x06?\x02\xfd\xc5hƗ|*\xc4pb\x06ͧ\re63v\xee;\xea\xade\x91\xc5\xcb\xdeu\xb2\xa8Ɓ\xf17\xb7\x95w\xbf\xb7\f\xb9V;\xee\xe0\xd5\xe8\x87\x7f\x13;Ou\a4D?[\x90\x8a.\xac\x9c)q\xbco:\v\xcf$\x1c\xef@\xe7\x8d\v~\xb7sWN\x8f}\xfc\x02\xa4\x06\xd0q+\xddyw̝\x00\x9b\xb1\x89\b\xc3\x1b7\x8f\xa2\x11\xf9+\xfd\x87\xda\xe4\x17\xb6\xc0'W\xb8\x01\xcd{-\xb7\xf2\xa8\xe7\xd9\x1c\x1b\x82أQU\x1aӡ\x9cN\x14E\x87d\xd3vэ.\xf8^ﶁ\xb1i\xc3Y\xc0\xa5\xbbG\x80DC\x16\a\xf2\xaa\xf7\x17|\xfd\v'\x1b.\x90\x8a\x05\xcf\xfe\xf7X\xa3,\x83\xac\xa0b\xbeڤ6\x06\xfcN\xaa\x90?\x06\xe3̯q\x8f\xec\xb4w,Öa\xcb0\xe5\x98r\xcc9f\x1c\xb3\x0eY\x87,×\xae\v\x83\\$Z\xd1W\v\xc2\xcc9f\x1c\xb3\x0eY\x87*ĄZ7\x1d\x98l.U\x8e\xfb<\x82\xf4\xb2\xbc\xcc\xed9\xd4\x1c\xd10\xddjɧ韸\xdf\a\xb8\x9d\x1f\x12\xfe\x01{P\xec\xf3+\xddyw\xca\x7fT\x8b\x1f\x14\x02\x16}\xab>՟jϵgڳ\xedY\xf6\xac\xfbV}\xab>՟jϵgڳ\xedY\xf6\xac\xfbV}\xa8\x01\x18_t\x9f\xb8\xd9\x17\xed*\xd0\xc4S\x98\r*5\"\x0e17\xf5J'o\xd1C\xea\x12~\xe4\r'\xd4˥oTI\xbf\xaaQ;N\x8ciw\xc2\x04\xc1u\xf7\xfdh}BOܖuD\xbaUh\xbd\x97\xcd\xfdR\x89\xdaB\x1b5\xff\x00\n\xc3\a~2/`\xb2\xf1}\xda\xf0È\xb8\xe28P\xfa\x84\x9f\xb9\x03\x86\xab\xd3b\xfd\xc1\x16\x9di\xf0\xb8\x1b\xa4\xfe\xa9D\xed'\xf4\xe0Dkn\x01\xd8\"\x10\xef\xf0\xbf)\x83#'\n\x1fP\x93\xf7+\xe0\x9d\x9bě\x1cmzL\x9f\xd5(\x9d\xa4\x1cr]qU\x12/&\xfd\\\xd1'\x19\xc1\xbe\xf7\xbb\x1c\xaa2b^\xa8Ɨ|\"Ǌ\x118}BOܬ}h1\x19=\x9c\xa4\xfe\xa9D\xed:1\xd5\x1c\n\xba\b\n\xdcG\xda8?\xe4\xbfVD\xe9\x15\x81Ú\xa3\x1a\a\xc2l_\xb8Rp\xfa\x84\x9f\xb9\x98u\x8b\x8c\x9du\x1a\xeb\xc2\x7fT\xa2v\xfa6\x06!{\x90kE\x00\xc1u\xd5-\xbep\xfa\x84\x9f\xb9\x8c#\xb7!\x11\xa3 \xff\x00\t\xfdR\x89\xdb\x04Cn-\xa3\xc1W)\x9ceAy*\xce7\x1b\xc9\xc2-:ӡ\xfd\xa6P\xfa\x84\x9f\xb9\x9b\x10l\x9a\xa0\U000482a2ì(\xb0\xce\xcbȔN\xd8\x1f\x97\x0f\xb9\xe0\xac3\xb9\xe3\"\xff\x00\xc3\xfaO\xdb\xc5\x18љB.h\xfa\x16\xfe\xf1(}BO\xdc\xe6\x13\x8eG\xf5\"F\xd69D\x98\x87\fU\xc5Y\xc6M\xe4\xfdKt\xa9a\x94>\xa1'\xeev\xf0}\xc7\x02$\x83[y7..8\xcf\xd5s\x0e\xb0\x88\xe1r\x87\xd4$\xfd\xd0\xc7\xf2\x9cN\xd2/cZN+\xd6C\x16C\x16C\x16C\x16C\x16C\x16C\x16C\x16C\x16C\x16C\x16C\x16C\x16K\x11{\xb5ޡ\xf5\t?t:\x01\xd7x\x9b\xde\xc8$\x82\xb4w-\x1d\xcbGr\xd1ܴw-\x1d\xcbGr\xd1ܴw-\x1d\xcbGr\xd1ܴw-\x1d\xcbGr\xd1ܴw(d\xc0u\xce\x12~\xe8dN\x06\xff\x00\x85Q\xaf\xf4\xcf\xdd\"\x1cjշ\\\xb6\xbc-\xaf\vk\xc2\xda\xf0\xb6\xbc-\xaf\vk\xc2\xda\xf0\xb6\xbc-\xaf\vk\xc2\xda\xf0\xb6\xbc-\xaf\vk\xc2\xda\xf0\xb6\xbc-\xaf\vk\xc2\xda\xf0\xb6\xbc'Bej\x7ft\\\x7f\xf0?\xff\xc4\x00'\x10\x00\x01\x04\x01\x04\x01\x05\x01\x01\x01\x01\x00\x00\x00\x00\x00\x01\x00\x10\x11! 01AQ\x81@a\xa1\xb1\xf0q\x91P\xd1\xff\xda\x00\b\x01\x01\x00\x01?!\xc8\xe8\x8f\xf8#\xd0\xc3Fq\x80Q\x844<(P\xf0\xa1CB\x85\n\x14(\xca\x17:p\xf0\xa3\x0e\xf0(`0\x19s\xa3\xce\x03G\x8cB\xe1\xc6\x01q\xa1\xde\x05\f\x06\x03.tynX79\x8c\x0f\b.\x1c`\x17\fW/y\x1d\x118\xdfyZ\xb5oj\xd8=\xabV\xad[q\xa9y^\x81x\xf5#G\x8cCG\xa1\x86:#\x18\xc6\x1a0\x8c\xa1\xa3\x18\xf41\xe8\xe3\x18\xff\x00\x89\x1ae\f\x06\x01R\xe1\x16\xa5ʧ\xa5J\x9f\x95N\x155e§(.\x1cc\xc3\x16\xadQ\x80\xf4cG\x8cF\x8f\x1a\x85\r\x00\xdci[\xf3\x80{ˌ\n\x1aV\xf7\xa0q\x19p\xfes\xf3\x87\x97\b\x907(\xc80A\aފ\xf3\x97\x18\x8d_8w\x81C\x00\xe1\a/Ώ/$P\xb8\x02P@\x00Fې\xbf\xde3@\"\a\a\x9c\xf8\xc4.\x1c`\x17\ft\x8e\x9f\x1a\xbc\x00\xb2hE\x94\x04Xz)\x0e\x92{T\x02\n\x11\x14\x00C\x8e\xb6D\x04\x89\x88E \x1d\xb2\xe3\x11\xe8{\xc0\xec\x86\x01\xc2\fQ~r\x907D\"\xda$\n\b\x10\x11\t=\xca\x04\x82\x027\x8b\x9c\x80.J\x10Y\x02\xa8\x1b!\xa9*\x11\xd1j_={d6\xc0\xf0\x82\xe1\xc6\x01p\xc7H\xa1\xe8\x82\x02x\x80IA\xc0\x05@r\x12#$\xec@ \x00\x02\xa9\xc8\x10b'x\xa2\x80`\x8e\x7f\xf1\x1e\x9e^\n\x82\x14\xaf\x12\x11\x9f\x96\x14\xfaH\xab\x91\xa6=)\xf4\x04\x81\xca &\x82c`\x04\x01'\xfcJ\xdc<\xee@\xb2\xe4\"\xeb$\xa1\x0e\x00\xc5\xe0\x02\v\xfe$Qd\x9b&\xc9;\xb8D6\xc5>\xe3\xa42y\x00\b[\x88\x82[\xc2\x10\x8e<b5\xa73\xa21\x9b+\x90\x12\xa5I\x00\xff\x00I_\xe8\x81\xda\x00\x06$\x01h\xf9(\x067T\x19\x9e\x81\xdd$\x02\xdaJs7J\b֛\x990āE\x04\x8fV\x9ce\xa5\xa5\xe5\xa5\x10\x1b\x9d\xf6DH\xf6\xb6\xc4#ňp((\xc0\xba\bn\x14\x84\t\x8107(\x03\t\xfe(\xf81\x87\x15\b\x0fm \x88\x88\x88\x88\x8d(1<\x02\xd3c`s\x16\x86\x14DC&C\x12{\xc6ZZ^^t\xca\x18\a\b \xc8\xf4(!<\x8fyA+#\ue5ce\xa1rP\x83\xc5$\xc7~\x9b\xf7\xfe\xe0xAp\xe3Н\x18\x8e\u0600\xd1EH0\x13\xbcT\xc0\x18\x11\x1d%\x1a\x9e\x9c\x15\x05\U0008b17c[\x81\xe1ڿqz\x0f\xdcrfjo\xdcrf\xa0c\xf5\x92\x80\xec\xb7\xd2\xf5G\x10\xe1\x14d\x80\t\x8d\xe3\xa4\x16Y\x04\x82\x8cy3\t\xdc\x14\x80\f\x84\x19\xde_\xe5\x18F\xdf\xe0P:\n\aA@\xe8(\x1d\x05\x03\xa0\xa0t\x14\x0e\x82\x81\xd0P:\n\aA@\xe8(\x1d\x05\x03\xa0\xa1\xd0P:\n\aA@\xe8!\x1b!\x8f\xa9\x88\\8\xc0.5\n\x18\a\n$\x11\xda\x04\xebm\x8aQڑ\x15o\xf2\x8d\xfb=\x9c\xe8\x88Y\x01&\x14xC\xb0M E\xe448W\xd2\xc4.\x1cz\x13\xa2\x11\x06{\x12\xf6,V!4{\x84\f\xd8o\x94o\xd9\xec\xd4LlI\xd8\x10\x9e\xcfrܘ0°\x7f\x85\xf0v#\xdf\x10\x89To\x9b\x1c\xe8W\xd2~Xh\f\xe9R\xa5J\x98\xbd50T\x82.\xea0\x12\x86\bD\x06\x10ɐ\x04\x14r\x00\xc5\xc3R\x15/\x94o\x9d\xf4T\xbe;\xef\x00} \x00\xe9R\xa5M\xcam\x9fIW\xe4'\xdaB\x1e\xf4y\n\x95*T\xd4+\xe9*T\xb9a\rMOH55*\xd09\fNI\x13/\xe0\x18\x12de|\xa3|\xef\xa3N\xe8\xf1h\x00\t ؆\x1f)\b\x1eȹ\xc9d\x9f|,\x90\x00$\x93\x00\x0eJ\x14F,\x1f^\xcd4(\xa9\xd0*X.\x82W?\x1e:ƟK\x11\xafjիV\xc5\x05j\xda\xde\xd0V\x8fs\x0f\x85\x02\x0e\xc8\x10bI\x11<\x15\"=\xf9_(\xdf;\xe8\xadGh)\x99\x916\xc28\x81ñ&aM\x17\x04\xec\x02\xb5j\xfbWګj\x9e\xefw\x1d\x10\x19\x03\x81@\xe0`@\x84\"\x8f\x06$\xd5\x02\xaf\xb5oO\xa4\xad[\x14%p\xad\xad\xed\x05p\x8c\xa3*\xd5\xf7\xa0q\x0e\x10b$B\f\xe0\x86\xae\bc\x008\x9fe\xf2\x8d\xfb=\xb4@\xab\x88\x9d\x0e\x90\u0083\x00\x0e\x065\x8d\xa3쁐\bßK\x10\xb8q\x80\\j\x140\x18\a\x16\xce\x19\x1f\xd0\xd6\xdan\xf6O\x94o\xd9퇔 \r\xa4\x1c\x11Ѓ<\x01\xb2?\xaaA\xff\x00\xd4\b2D\x007%\x00|\xc2-x\x05\x89\x00A\x94SnA\xd5?\n\xfaX\x85Ì\x06>t\x0e\x9f\vq\xa0Pą\xb4:\x05\xb4q\x81V [?g\xb6\x00\x00\xf7&\xc0\x86\x87\xeeM˵\x13Gb\xaa\xdc2x\x12\x9a\xb3\x91\xc6\a\x01+\x90\v\xe0\x11\x85>\x97\xaa(`3*a$\xeb\xb6\tx%>\xe2\xdfO\xe9\xfe\xb07\xddDg\xcfG@\xe1\x06\x11\x03\xfc\x0f¾\x96#\x01\xe8Na\x86\x17\x00\x1b\xdf\xde\x1f(}0\xfa$\xc0\x0e\xd4hv\xfd\xcePЎ\x01(\x10a{\"DL\x932j\x11k\xe9?>\x9c\xa1\x80\xc0`f*\x88\xb9\xf7A\xe5H\x03|\xbf\xee\xf6c\xe4C\xf8\xaf\xdaW\xed+\xf0\x15\xfbJ\xfd\xa5~ҿ)_\xb4\xaf\xcaW\xed+\xf2\x95\xfbJ\xfc\xa5~\xb2\xa32NP K\xd3\xe9`P\\8\xc0j\x940\x1a$t\x8f\x1d\xe3\xb1\xd0Fƽ\x14DDDDDDDD\a\x90\x10OA\xbe\x96#Z\x14(P\xd0\xc5\x06\x86\x01\xe1\x05\b\x86\x84@$\x80*n\x81&\x90\x12\v\xcbJ\x96\x92\xa4\xa9RZZ^T\xbf\xd2hp\xb8xxA\xa1\xa1F\x81\xd1\x18\x98\"\x0e\xc5P\"\xb0L\x8fM\x99\x99\x99\x99\x99\x99\x99\x99\x99\x99\x9b\f\xf8\x91\x1c\xbf,4\x8e\x99\xc48~\x1e\xd79[\xf2\xad\xc3\xde\\b0\b7\r\xc6\x17\xa0tB\x9c')S\x84\xb8y\xd4\xe31\x8c\xe5lP\xc0=\xbd\xe9[\xf3\x80{kÅnP\xc0=\xbd\xb5\xabkV\xadZ\xb6*\xdaի{AZ\xb5jիV\xadZ\xb5jիV\xadZ\xb4%Z\xb5jիV\xadZ\xb5n%\xadZ\xb5mh+V֭Ng\x11\xa5Ψ{ˌG\xfci\xff\x00\x91jիV\xf6\xadZ\xb5j\xda\xdaյ\xabV\xadZ\xb5jիV\xadZ\xb5jիV\xadZ\xb5jիV\xf6\xadZ\xb5j\xda\xdaյ\xabV\xbc\xaf+\xca\xf2\xaf\xb68\xf9A\x05h/,W\x95\xe7\x1f+\xca\U000bcfd5}\xb0W\xdby^W\x95\xe5yW\xda\xe3u\xe5\xb9`\xb8\xca\xd0\\n\xbc\xa2\xbc\xaf:\a\x11\x80n\x1e3\x87\xe5C\x87\x8c\xb8\xc4.3\v\x8c#@\xe6\x18c\xe7?:\x9es\xe7_\u0381xQ\xa5Y\xd6a\xeb(\xacF\xad4\xa9R\x14\xa9b\xf2\xa9K\xca\nT\xb4\x85-*\x9aT\xa9R\x1aT\xa9R\x82\x96\x95!J\x95*T\x85*q\x95J^PR\xa5\xa5KAP\xad\xa1\x8b\xde\x01AA[ڏv\x87\x86\x8f|!A`\xa1\xa1APT\x15\x05B\xb5\r\xcb\x05¼`\xa0\xd6֠\xe8\x0ea\x87\xaf冿\xff\xda\x00\f\x03\x01\x00\x02\x00\x03\x00\x00\x00\x10\xf3M\x1c\xf3\x8f(\xd3\xcd4\xe3\x0f0\xd3\f0\xf3\xce4s\xcf<\xe3\xcc<\xf1O\x00\xf2\x8f(\xf3\xca<\xf3\xcf\x14\xd1M<\xf2\x8f8\x03\xc5<\x03\x8d<\xf3\xcf\x14\xe3\xcf,\xf3\xc7<\xf0\xcf,qK\fs\x8f<\xd3O,\xf3\xcb<\xf3\xc7\x1c\xf3\xcf,\xe3\xc74\xb2\xcf,\xf3\xcb,\xf3\xc7<\xf3\xcf<\xf3K<\xf3\xcf\f\xf2\x8f(1\xc7\x1c\xb0\xcf\f\xf0K<\xf3\x87,\x13\xc7<\xa2\xcb<\xf3\xcf\x04\xf3\x8f,\xe3\xcd<\xf2\x8f8\xf1\b<\xf3\x8f,S\xcf<\xf1\xca<\xf1\xcf\x04\xf2\xcf\f\xe1\xcb<\xf3\xcf\x10\xb3\x8e<\xf2\x8f<\x13\xc7<2\xcf<\xf1\xcf\x00\xf2\xcf\f\xb1\xcf<\xe2\xc6$cE<\xf2\xcf,\x13\xc7<2\xcf<\xf3\xcf\x14\xf3\xcf8\xf3\xcd\b\xe0\xcd\f\xf0\x8b\x1c\xf2\xcf<s\xcf<\xf3\xcf\f\xf1\xcf \xb2\x8f\x101C\x10\xa1\xcc<\xf3\xc3 \xe2\x0f8A\xc5,\xf1\xcf<\xf3\xcf\x14\xf3\x8f03G\x18\x00\x00\x00\x00\x00\x00@\x8f<\xc3\xcd<\xc3\xcf<\xf3\xcf\x04\xf2\xcf\b\xa1\x8f\x00\xe0\xc6<\xf1\x0f<\xa0O4\x13\xc7<\xd3\xcf<\xb3\xcf\x14\xf3\xc9,r\r\x00\xa0\x80,\xe1\x024\xa1O\x1cS\xcf<s\xcf<\xb2\xc7\x04s\x8f\x00#\x85\x00\xb3\xcf,\x10\f(\xa2C,C\xcd,\x00\xcb<\xf3\xcf\x04\xf2\xcf,\xe2N\x00\xf2\x81\f\x80\x00 \xa0\x0f<3\xc7<s\xcb<\xf3\xcf\x04\xf2\xce8\xf0\xc4\x00\xe1\x00\x00\x00\x00\x14\x81\x0f4s\xc7<\xf3\xcf<\xf3\xcf\x00\xf2\x8f,\xf2\xc8\x00\x80\b\x00\x00\x00\x00\x01\x0f,\x13\xc7<s\xcf01\xc7\x04\xb2\xcf\b2\xc5\x00\x00\x00\x00\x00\x00\x00\x00K4\x13\xc7,qC<\xf3\xcf\x14\xf2\xcf\x18\x93\xc1<\xf2\x0f\b\xf1J<\xf2\xcf<c\xc3,\x93\xca<Å\x04\xe2\x8f\x10\x83\r4\xc2\x0e\x10\xc1\b \xc2D,SD(\xc1@0\xf3\xcf\x1c\xf2\xcf<\xf3\xcb8\xf3\xcf<\xf1\xcb<\xf2\xcf<s\xcf<\xf3\xcf<\x00\x05$0\x84\x10\x12\x01\f \x01\b\x10\x02\x00\x00\x014\x10\xc3(\x81\x00\f\xf3\xcf\x14\xf2\xce(\xb3\xc5<\xf2\x8f\f\xf1\xcb8\xf2\xcf43\xcf<3\xca<\xc3\r\x14\xa1\x8f0Å4\xe2\r0\xc1\n0\xc2\f<sM8\xc3H<3\xc7\x14\xf3\xca\b\xf3\xc7\x1c\xb2\xcf,p\xcb\f1\xc7\x14S\xcf,s\xcb<\xff\xc4\x00\x14\x11\x01\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x90\xff\xda\x00\b\x01\x03\x01\x01?\x10H?\xff\xc4\x00\x14\x11\x01\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x90\xff\xda\x00\b\x01\x02\x01\x01?\x10H?\xff\xc4\x00+\x10\x00\x00\x04\x04\x06\x01\x03\x05\x01\x00\x00\x00\x00\x00\x00\x00\x00\x101q\x01\x11 \xf0!0@AQaP\x81\x91\xa1`\xb1\xc1\xe1\xf1\xd1\xff\xda\x00\b\x01\x01\x00\x01?\x10\xa9\x1e(\x8d\v\x89պ\x93\xa8q8\xdc\x1c\x1cn\x0e\x0e'\a\a\a\a\aT\xecÍ\xc1\xd4C\xc9\x00\x04f\x90\xa0\x8f\n\x00\x8a\x17HF\x84\b\xfa\b\xc0\x00;ćh]\xa0\x1dK\x89\xd4:\xa7\x13\xa9v\x85\xda7R\xef\b\xec\xb4\x04x\x80\x00\x01\x01\x14\x11\xf4\x80\x01\x01\x1a\xb0Fh\x80\x8d(\x8c\xe2U\xe5FTðx\x04\xb2\xe4fʌ<\b\x00\\\x84\xc3\xf3\xb07\x01\xfc\b\x1a\n\f\x89\x1d\x00F\x84#P\xf40\x01\xfc\x10 \x80\xe3\x1e\u0086\x18\xd0]\xcc\xc4\x18\xb3\t\x11\xa1\x85!\x1aP\v\xd4\x18\t\xd8\xe5M\xc1\xd21\xa0_\x02\xa6\b\x94\x0e\x8c\xf9O\xdcG\xa0\xcc5`P\xca\xc4H\xe5\v\x04PF\x84 #F\x0f\x14݁\xbb\x1c\xc8\x7f\x96x\x14 \xba!\x82\x86,\a\a?1\x1e\x1c\x00\x85'D\xfa\xed\xb1Ca.\xde`\xa3\x96\x8f\f\x1c@\xfb\xe0\x1abHF\xd8OX\x88\x0f\xf4\x05(\x92\xa0[\xf1\xecCX\xa01\x8d\"\x00{\x98\xc0\xa8L\x18\x82\x1cD}\xc0\x88\x1f\x02p5\x04\x95\xa3<.EÍ\x1a1\x06\x0f\xe0\x041\xe0Q\x8e\x99\x18\xac\xa7H()\x12Z82\"M&D\xf3\xabe,&\x13\r\x84\xc1\xec(?\xc1=\x00\x15\t\x1e\x929\x06&YP \vH\x92P\x1b\tG\xe2\xb2È\x88\x88\x88\x8e\xc0QR\t\x18\x97\x01\x80\x01HDG\x809\x15&\x13\t\x86\xc3fZ\x022\x0f\xd8\b\x92\"\xd9@\x9cG4k\xf2G\x10p\"\x84\x04\xf3E\x12i\xbe\u0082\xf5\xe0\x9d\x1f\xda0\x81o\x112D\x0f,\x80\x11\xe4\x80\xf7\x01\x04\xe8X\xb88\x8c2\xee\"\xce$k8\x8b\xb8\x91\xae\xe2.\xe2.\xe2,\xe2.\xe2F\xbb\x88\xbb\x88\xb3\x88\xbb\x88\xb1\x88U1\x16!\\w\xe0\xc7\xdc\x02\t\x0fBX(\x88\x1d\xa2,t\x18\x9f\b\xe4\xec\\i\x01?\xff\x00\xff\x00\xff\x00\xff\x00\xff\x00\xfc\xff\x00\xfe\xc1\xd3/\x91\xf7x@\x00\t\"\na\xb1{a\x04\xa9ظ\xa8\x00R(\xc0\x0f\x8f\x94\xe38\x16\xaeF\xf1qި#)\xf11\x11\"I5\xb0\xec g\xb1q@\x1a\x890X\x8f\xdc\t$JH\v6-\xd5\x00\x80\xc2*\x87\x15Z\xb9\x1b\xc5\xd7t\x91\xa5\x01\x19@\xf8\x01\x85\x88\xf7\b\x04%\x00C\xd4G\xc0\x86A%\x8b\x8c\xd0\x02\x00T\xc3\xfa\x02\b\xb9a\au \x16\xaeF\xf1uި\x00\x11\x96\xc2\x0e\x17\"\xe2X\x80\xfe\x02D0X\xb8\xa0\x0f\x890\xa2\x91\xf4\n%\a\x02\xec\xe7\x8fdz\x89\xd1(tt\x00O8?\x15b\xff\x00i\xc2`>A\xc9\x0f\x91\x02\xc7j䮻\xa5\x1aP # \x10O\x91$\"\x01\b\xe2\x86\xc3\x10);\fH\x8b\x17\x14\x80'\xc4F\xc0\xe3\x81\xfe8\xebA\xfc\x02\xa0\x85'\xf5^6\xe5EI\x00$%a\x82\x80\xc7G\x8a\x02\x1e\xb5rW\x1d\xd0#\xc1\x00\x01\x81\x10J\x12\x0fq\x10\x05\x16.2\xc1/;f\x96\xa1\x15\x18\x99\xb0\x93%\x8f\xb1\x92\xb5rW]\xea\xc0FY\xb8\x8aJ\x8f\x88\x89\xdc\\\x05\x8b\x8a\x81!\xfc\xd1\xe1#\xedǤR-\xc4\xf4`&}\u0089\xb7\xa5Y \xd0v\x1b\x89\x83\xb3\x02\xb5r7\x8b\x8e\xf4A,\x8a3\f\x02\x04,\x04l\xe7\xeb\x88\xda\x17.\xc4@\xe6\x84Z\xa0c\x87\xfb\xb0\x88\xfd\x81\xc0\x04a\x9f\xb0\x80\xb6TfÔ\x96'(Ѿ\x8f\x805\x02Ej\xe4\xae;\xf0\xe1\xef\x00J\x85\xed5\\\x95 \xd8\f!\xf7:\x1f\xc04\x8e\xb27РJr\xe1\x18\x95\xab\x91\xbc]w\xaa\x11\x9aC&\xd0\xea\xa0]\x88h\xc1\xfc\x18\xb1\x0e\xaai4o\xa6\x0f\x00\x98\x14\x06FB\xd5\xc9\v\x8e\xf5D\x04f\x13:C\xa0l\x9c\x1cX0Ɛ~\x84\xb4\x19\xe2\x10\x04!(J\x12\x84\xabv\x05\x8a\x05\xab\x92\xba\xee\x84\x04x0\x12o\x1f\x94!\xa5\x93\x13\x0333333333\xee\x8bS\n\xe3\xbd\x1a@\xe0\xe1!8\x90\x10N\xa4q\x1cd\x83\xb4\xa4\x10\xa3\x02*\x0e\"o'\x87\x97@\xe8\x0f\x1d\x04\xf2y\xbc<\xee;'\x1a(8\xdcg\x13\x83\xb2\x11\x9e\x04E\xbcH\xa0\xa6\x9a\xee\xee\xee\xee\xee\xee\xee\xee\xee\xee\xfaiJ\u0091\x1fO\x00\x00\x01\x942\xa6\x06Pʌ\xd4\f\xc8 #(F\xb8\x02\x024\xa0\x02<\xc0\x00\x00\x00\x00\x00\x02O\xa5\xc03\xeap\x00\x00\x00\x00\x00\x00b2\x1cFG\xd1L\x85\x14\t\x1d\x88ʜ\x86C!\xc4\xf2\xab\xb9\x0e#!\x90\xe2}\x95\x122\x83\"\xa42\xd3Aպ\x83\xaa;P\ad#8ƾ9\x9ct\xd69s\x84\x9eT\x02@\xc1\xd8\x18$$\x1c\x84\x90\xe4#\x04\x85\xd8$)\f\xc0\xc0\xc1\xd8R\x06\t\x04\x81\x02B`\xec\x12\t\x04\x81\x83\xb00IL\x84\x90\xe4$\x82B\x900\xc7\x1aBFH\"\x92r\xc8r\x14\xf4\\b\x04\x85% \x0e$\x94\x11\xa5\x00\x11\xe2\x823\xff\x00\xff\xd9"),
 }
+var resourceNutrientsCsv = &fyne.StaticResource{
+	StaticName: "nutrients.csv",
+	StaticContent: []byte(
+		"name,energy_kcal,protein_g,fat_g,carbohydrate_g,fibre_g,grams_per_piece,density_g_ml\nflour,364,10.3,1.0,76.3,2.7,,0.53\nwhole wheat flour,340,13.2,2.5,72.0,10.7,,0.51\ncornstarch,381,0.3,0.1,91.3,0.9,,0.54\nbreadcrumbs,395,13.4,5.3,71.9,4.5,,0.45\noats,389,16.9,6.9,66.3,10.6,,0.41\nrice,365,7.1,0.7,80.0,1.3,,0.85\npasta,371,13.0,1.5,75.0,3.2,,\nquinoa,368,14.1,6.1,64.2,7.0,,0.72\nbread,265,9.0,3.2,49.0,2.7,30,\nsugar,387,0,0,100,0,,0.85\nbrown sugar,380,0.1,0,98.1,0,,0.83\nicing sugar,389,0,0,99.8,0,,0.56\nhoney,304,0.3,0,82.4,0.2,,1.42\nbutter,717,0.9,81.1,0.1,0,,0.91\nolive oil,884,0,100,0,0,,0.91\nvegetable oil,884,0,100,0,0,,0.92\nmilk,61,3.2,3.3,4.8,0,,1.03\ncream,340,2.8,36.0,2.8,0,,0.99\nsour cream,193,2.4,19.4,4.6,0,,1.0\nyogurt,61,3.5,3.3,4.7,0,,1.04\ncoconut milk,230,2.3,23.8,5.5,2.2,,0.97\negg,143,12.6,9.5,0.7,0,50,\ncheese,402,24.9,33.1,1.3,0,,\nparmesan,431,38.5,28.6,4.1,0,,\nmozzarella,280,28.0,17.0,3.1,0,,\ncottage cheese,98,11.1,4.3,3.4,0,,\ntofu,76,8.0,4.8,1.9,0.3,,\npotato,77,2.0,0.1,17.0,2.2,170,\nsweet potato,86,1.6,0.1,20.1,3.0,130,\nonion,40,1.1,0.1,9.3,1.7,110,\ngarlic,149,6.4,0.5,33.0,2.1,5,\ncarrot,41,0.9,0.2,9.6,2.8,60,\ntomato,18,0.9,0.2,3.9,1.2,120,\ncanned tomatoes,32,1.6,0.3,7.3,1.9,,1.03\ntomato paste,82,4.3,0.5,18.9,4.1,,1.1\nbell pepper,31,1.0,0.3,6.0,2.1,120,\nzucchini,17,1.2,0.3,3.1,1.0,200,\neggplant,25,1.0,0.2,5.9,3.0,300,\nspinach,23,2.9,0.4,3.6,2.2,,\nmushroom,22,3.1,0.3,3.3,1.0,18,\ncabbage,25,1.3,0.1,5.8,2.5,,\nbroccoli,34,2.8,0.4,6.6,2.6,,\ncauliflower,25,1.9,0.3,5.0,2.0,,\npeas,81,5.4,0.4,14.5,5.7,,\nlemon,29,1.1,0.3,9.3,2.8,100,\nlemon juice,22,0.4,0.2,6.9,0.3,,1.03\napple,52,0.3,0.2,13.8,2.4,180,\nbanana,89,1.1,0.3,22.8,2.6,120,\nstrawberry,32,0.7,0.3,7.7,2.0,12,\nchicken,215,18.6,15.1,0,0,,\nchicken breast,120,22.5,2.6,0,0,,\nbeef,250,26.0,15.0,0,0,,\nminced meat,254,17.2,20.0,0,0,,\npork,242,27.3,13.9,0,0,,\nbacon,541,37.0,42.0,1.4,0,,\nham,145,21.0,6.0,1.5,0,,\nsalmon,208,20.4,13.4,0,0,,\ntuna,132,28.0,1.3,0,0,,\nshrimp,99,24.0,0.3,0.2,0,,\nchickpeas,164,8.9,2.6,27.4,7.6,,\nlentils,353,25.8,1.1,60.0,10.7,,\nbeans,333,23.6,0.8,60.0,15.2,,\nalmonds,579,21.2,49.9,21.6,12.5,,\nwalnuts,654,15.2,65.2,13.7,6.7,,\nhazelnuts,628,15.0,60.8,16.7,9.7,,\npeanut butter,588,25.0,50.0,20.0,6.0,,1.09\ntahini,595,17.0,53.8,21.2,9.3,,1.08\nchocolate,546,4.9,31.0,61.0,7.0,,\ncocoa powder,228,19.6,13.7,57.9,37.0,,0.45\nbaking powder,53,0,0,27.7,0.2,,0.9\nbaking soda,0,0,0,0,0,,1.1\nyeast,325,40.4,7.6,41.2,26.9,,\nsalt,0,0,0,0,0,,1.2\npepper,251,10.4,3.3,64.0,25.3,,0.5\npaprika,282,14.1,12.9,54.0,34.9,,0.46\ncinnamon,247,4.0,1.2,80.6,53.1,,0.56\nginger,80,1.8,0.8,17.8,2.0,,\nparsley,36,3.0,0.8,6.3,3.3,,\nbasil,23,3.2,0.6,2.7,1.6,,\ncoriander,23,2.1,0.5,3.7,2.8,,\nwater,0,0,0,0,0,,1.0\nstock,7,1.0,0.2,0.4,0,,1.0\nwine,83,0.1,0,2.6,0,,0.99\nvinegar,18,0,0,0.04,0,,1.01\nsoy sauce,53,8.1,0.6,4.9,0.8,,1.1\nmustard,66,4.4,4.0,5.8,3.3,,1.05\nmayonnaise,680,1.0,75.0,0.6,0,,0.91\n"),
+}
@@ -0,0 +1,321 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	"golang.org/x/exp/slices"
+)
+
+// Prefix of navigation tree node IDs in the "Collections" branch
+const collectionNodePrefix = "collection:"
+
+// RecipeCollection is a named, ordered list of recipes, e.g. "Christmas" or "Quick lunches"
+type RecipeCollection struct {
+	Id        string   `json:"_id,omitempty"`
+	Name      string   `json:"name"`
+	RecipeIds []string `json:"recipeids"`
+}
+
+// Collections are stored in a separate MongoDB collection next to the recipes
+func collectionsCollectionName() string {
+	return credentials["collection"] + "_collections"
+}
+
+// getCollections returns all recipe collections sorted by name
+func getCollections() []RecipeCollection {
+
+	body := map[string]interface{}{
+		"collection": collectionsCollectionName(),
+		"filter":     map[string]string{},
+		"sort":       map[string]int{"name": 1},
+	}
+
+	var response struct {
+		Documents []RecipeCollection
+	}
+
+	if err := callDataAPI("find", body, &response); err != nil {
+		errorDialog := dialog.NewError(err, mainWindow)
+		errorDialog.Show()
+		return []RecipeCollection{}
+	}
+
+	return response.Documents
+}
+
+// saveCollection inserts a new collection or updates an existing one
+func (recipeCollection RecipeCollection) saveCollection() bool {
+
+	var err error
+
+	if recipeCollection.Id == "" {
+		body := map[string]interface{}{
+			"collection": collectionsCollectionName(),
+			"document":   recipeCollection,
+		}
+		err = callDataAPI("insertOne", body, nil)
+
+	} else {
+		body := map[string]interface{}{
+			"collection": collectionsCollectionName(),
+			"filter":     map[string]map[string]string{"_id": {"$oid": recipeCollection.Id}},
+			"update": map[string]interface{}{"$set": map[string]interface{}{
+				"name":      recipeCollection.Name,
+				"recipeids": recipeCollection.RecipeIds,
+			}},
+		}
+		err = callDataAPI("updateOne", body, nil)
+	}
+
+	if err != nil {
+		errorDialog := dialog.NewError(err, mainWindow)
+		errorDialog.Show()
+		return false
+	}
+
+	return true
+}
+
+func (recipeCollection RecipeCollection) deleteCollection() bool {
+
+	body := map[string]interface{}{
+		"collection": collectionsCollectionName(),
+		"filter":     map[string]map[string]string{"_id": {"$oid": recipeCollection.Id}},
+	}
+
+	if err := callDataAPI("deleteOne", body, nil); err != nil {
+		errorDialog := dialog.NewError(err, mainWindow)
+		errorDialog.Show()
+		return false
+	}
+
+	return true
+}
+
+func findCollection(id string) (RecipeCollection, bool) {
+
+	for _, recipeCollection := range recipeCollections {
+		if recipeCollection.Id == id {
+			return recipeCollection, true
+		}
+	}
+
+	return RecipeCollection{}, false
+}
+
+// collectionNodes returns navigation tree node IDs of all collections
+func collectionNodes() []string {
+
+	nodes := []string{}
+	for _, recipeCollection := range recipeCollections {
+		nodes = append(nodes, collectionNodePrefix+recipeCollection.Id)
+	}
+
+	return nodes
+}
+
+// reloadCollections loads collections from the database and updates the navigation tree
+func reloadCollections() {
+	recipeCollections = getCollections()
+	navTree.Refresh()
+}
+
+// getRecipesInCollection returns one page of recipes in a collection, in the order of the collection
+func getRecipesInCollection(recipeCollection RecipeCollection, offset int, perPage int) (results []Recipe, totalCount int) {
+
+	if len(recipeCollection.RecipeIds) == 0 {
+		return []Recipe{}, 0
+	}
+
+	recipesById := map[string]Recipe{}
+
+	for _, recipe := range getRecipesByIds(recipeCollection.RecipeIds) {
+		recipesById[recipe.Id] = recipe
+	}
+
+	// Recipes deleted since they were added to the collection are skipped and not counted
+	allResults := []Recipe{}
+	for _, id := range recipeCollection.RecipeIds {
+		if recipe, exists := recipesById[id]; exists {
+			allResults = append(allResults, recipe)
+		}
+	}
+
+	allResults = withoutExcludedAllergens(allResults)
+	totalCount = len(allResults)
+
+	if offset >= totalCount {
+		return []Recipe{}, totalCount
+	}
+
+	end := offset + perPage
+	if end > totalCount {
+		end = totalCount
+	}
+
+	return allResults[offset:end], totalCount
+}
+
+// displayCollectionResults displays the first page of recipes in a collection
+func displayCollectionResults(recipeCollection RecipeCollection) {
+
+	startQuery("collection")
+	currentQuery["collectionId"] = recipeCollection.Id
+
+	currentRecipes, currentCount = getCurrentResults(0)
+
+	allPages := int(math.Ceil(float64(currentCount) / float64(config.resultsPerPage)))
+	currentPage = 1
+	displayResults(allPages, recipeCollection.Name)
+}
+
+// showAddToCollectionDialog lets the user pick an existing collection or name a new one for a recipe
+func showAddToCollectionDialog(recipe Recipe) {
+
+	collectionNames := []string{}
+	for _, recipeCollection := range recipeCollections {
+		collectionNames = append(collectionNames, recipeCollection.Name)
+	}
+
+	collectionSelect := widget.NewSelectEntry(collectionNames)
+	collectionSelect.SetPlaceHolder(tr("Collection name"))
+
+	formItems := []*widget.FormItem{widget.NewFormItem(tr("Collection"), collectionSelect)}
+
+	dialog.ShowForm(tr("Add to collection"), tr("Add"), tr("Cancel"), formItems, func(confirmed bool) {
+
+		name := strings.TrimSpace(collectionSelect.Text)
+
+		if !confirmed || len(name) == 0 {
+			return
+		}
+
+		chosenCollection := RecipeCollection{Name: name}
+		for _, recipeCollection := range recipeCollections {
+			if strings.EqualFold(recipeCollection.Name, name) {
+				chosenCollection = recipeCollection
+				break
+			}
+		}
+
+		if slices.Contains(chosenCollection.RecipeIds, recipe.Id) {
+			return
+		}
+
+		chosenCollection.RecipeIds = append(chosenCollection.RecipeIds, recipe.Id)
+
+		if chosenCollection.saveCollection() {
+			reloadCollections()
+		}
+
+	}, mainWindow)
+}
+
+// showEditCollectionDialog allows renaming a collection, reordering and removing its recipes and deleting the collection
+func showEditCollectionDialog(recipeCollection RecipeCollection) {
+
+	// Titles come from the local search index, which holds all recipes
+	recipeTitles := map[string]string{}
+	if localIndex != nil {
+		for _, recipe := range localIndex.recipes {
+			recipeTitles[recipe.Id] = recipe.Title
+		}
+	}
+
+	nameEntry := &widget.Entry{PlaceHolder: tr("Collection name"), Text: recipeCollection.Name}
+	recipeIds := append([]string{}, recipeCollection.RecipeIds...)
+	recipeRows := container.NewVBox()
+
+	var refreshRows func()
+
+	refreshRows = func() {
+
+		recipeRows.RemoveAll()
+
+		for j, id := range recipeIds {
+
+			position := j
+
+			title, exists := recipeTitles[id]
+			if !exists {
+				title = tr("(unknown recipe)")
+			}
+
+			upButton := &widget.Button{Icon: theme.MoveUpIcon(), OnTapped: func() {
+				recipeIds[position-1], recipeIds[position] = recipeIds[position], recipeIds[position-1]
+				refreshRows()
+			}}
+
+			downButton := &widget.Button{Icon: theme.MoveDownIcon(), OnTapped: func() {
+				recipeIds[position+1], recipeIds[position] = recipeIds[position], recipeIds[position+1]
+				refreshRows()
+			}}
+
+			removeButton := &widget.Button{Icon: theme.DeleteIcon(), OnTapped: func() {
+				recipeIds = slices.Delete(recipeIds, position, position+1)
+				refreshRows()
+			}}
+
+			if position == 0 {
+				upButton.Disable()
+			}
+
+			if position == len(recipeIds)-1 {
+				downButton.Disable()
+			}
+
+			recipeRows.Add(container.NewBorder(nil, nil, nil, container.NewHBox(upButton, downButton, removeButton), widget.NewLabel(title)))
+		}
+
+		recipeRows.Refresh()
+	}
+
+	refreshRows()
+
+	var editDialog dialog.Dialog
+
+	deleteButton := widget.NewButtonWithIcon(tr("Delete collection"), theme.DeleteIcon(), func() {
+		dialog.ShowConfirm(tr("Delete collection"), fmt.Sprintf(tr("Delete collection %s?"), recipeCollection.Name), func(confirmed bool) {
+
+			if confirmed && recipeCollection.deleteCollection() {
+				editDialog.Hide()
+				reloadCollections()
+				navTree.UnselectAll()
+				navTree.Select("All recipes")
+			}
+
+		}, mainWindow)
+	})
+
+	recipeScroll := container.NewVScroll(recipeRows)
+	recipeScroll.SetMinSize(fyne.NewSize(400, 300))
+
+	content := container.NewBorder(nameEntry, deleteButton, nil, nil, recipeScroll)
+
+	editDialog = dialog.NewCustomConfirm(tr("Edit collection"), tr("Save"), tr("Cancel"), content, func(confirmed bool) {
+
+		if !confirmed {
+			return
+		}
+
+		if name := strings.TrimSpace(nameEntry.Text); len(name) != 0 {
+			recipeCollection.Name = name
+		}
+		recipeCollection.RecipeIds = recipeIds
+
+		if recipeCollection.saveCollection() {
+			reloadCollections()
+			displayCollectionResults(recipeCollection)
+		}
+
+	}, mainWindow)
+
+	editDialog.Show()
+}
@@ -1,10 +1,20 @@
 package main
 
-// App settings
+// App settings, the user can change some of them in preferences
 func setConfig() {
 
 	config.maximumImageSizePx = 300
 	config.resultsPerPage = 10
 	config.desktopDefaultWidth = 1500
 	config.desktopDefaultHeight = 800
+	config.notCookedRecentlyDays = 30
+	config.defaultCurrency = "EUR"
+	config.thumbnailSizePx = 100
+	config.imageCacheSize = 50
+	config.jpegQuality = 85
+	config.theme = themeDark
+	config.unitSystem = unitSystemOriginal
+	config.language = languageEnglish
+	config.resultsView = resultsViewList
+	config.resultsPaging = resultsPagingPages
 }
@@ -0,0 +1,529 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/data/validation"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	"golang.org/x/exp/slices"
+)
+
+// Price list is kept in the app's private storage, like manual nutrition links
+const priceListFile = "priceList.json"
+
+// Sorting results by cost needs costs of all matching recipes, so at most this many are fetched
+const costSortLimit = 1000
+
+// PriceEntry is the price of one package of an ingredient, e.g. 1 kg of flour for 1.20 EUR
+type PriceEntry struct {
+	Ingredient  string  `json:"ingredient"`
+	PackageSize float64 `json:"packagesize"`
+	PackageUnit string  `json:"packageunit"`
+	Price       float64 `json:"price"`
+	Currency    string  `json:"currency"`
+}
+
+// CostWarning describes an ingredient that could not be included in the cost
+type CostWarning struct {
+	Ingredient Ingredient
+	Reason     string
+}
+
+// RecipeCost holds the total cost of a recipe for each currency used in the price list
+type RecipeCost struct {
+	Totals   map[string]float64
+	Warnings []CostWarning
+}
+
+var priceList []PriceEntry
+
+func loadPriceList() {
+
+	priceList = []PriceEntry{}
+	loadLocalJSON(priceListFile, &priceList)
+}
+
+func savePriceList() error {
+
+	sort.Slice(priceList, func(i, j int) bool { return priceList[i].Ingredient < priceList[j].Ingredient })
+	return saveLocalJSON(priceListFile, priceList)
+}
+
+// findPrice returns the price list entry for an ingredient.
+// Like findFood, it ignores plurals and synonyms and prefers the longest entry name contained in the ingredient name.
+func findPrice(ingredientName string) (PriceEntry, bool) {
+
+	if priceList == nil {
+		loadPriceList()
+	}
+
+	var bestEntry PriceEntry
+	bestLength := 0
+
+	for _, variant := range ingredientSynonyms(normalizeFoodName(ingredientName)) {
+
+		variantTerms := tokenize(variant)
+
+		for _, entry := range priceList {
+
+			entryTerms := tokenize(entry.Ingredient)
+
+			if len(entryTerms) == 0 || len(entryTerms) <= bestLength || !containsAllTerms(variantTerms, entryTerms) {
+				continue
+			}
+
+			bestEntry = entry
+			bestLength = len(entryTerms)
+		}
+	}
+
+	return bestEntry, bestLength > 0
+}
+
+// unitAmount converts a quantity to grams, millilitres or pieces and returns which of them it is
+func unitAmount(quantity float64, unit string) (amount float64, dimension string, known bool) {
+
+	unit = normalizeUnit(unit)
+
+	if grams, exists := weightUnits[unit]; exists {
+		return quantity * grams, "weight", true
+	}
+
+	if millilitres, exists := volumeUnits[unit]; exists {
+		return quantity * millilitres, "volume", true
+	}
+
+	if pieceUnits[unit] {
+		return quantity, "pieces", true
+	}
+
+	return 0, "", false
+}
+
+// ingredientPackages returns how many packages of a price list entry an ingredient amount uses
+func ingredientPackages(ingr Ingredient, entry PriceEntry) (float64, error) {
+
+	if entry.PackageSize <= 0 {
+		return 0, fmt.Errorf(tr("package size of %s is not set"), entry.Ingredient)
+	}
+
+	ingrAmount, ingrDimension, known := unitAmount(ingr.Quantity, ingr.Unit)
+	if !known {
+		return 0, fmt.Errorf(tr("unit %s is unknown"), ingr.Unit)
+	}
+
+	packageAmount, packageDimension, known := unitAmount(entry.PackageSize, entry.PackageUnit)
+	if !known {
+		return 0, fmt.Errorf(tr("package unit %s is unknown"), entry.PackageUnit)
+	}
+
+	if ingrDimension == packageDimension {
+		return ingrAmount / packageAmount, nil
+	}
+
+	// Weight and volume can be compared through density or piece weight from the food table
+	if foodTable == nil {
+		loadNutrition()
+	}
+
+	food, found := findFood(ingr.Name)
+	if !found {
+		return 0, fmt.Errorf(tr("unit %s is incompatible with package unit %s"), ingr.Unit, entry.PackageUnit)
+	}
+
+	ingrGrams, err := ingredientGrams(ingr, food)
+	if err != nil {
+		return 0, fmt.Errorf(tr("unit %s is incompatible with package unit %s"), ingr.Unit, entry.PackageUnit)
+	}
+
+	packageGrams, err := ingredientGrams(Ingredient{Name: ingr.Name, Quantity: entry.PackageSize, Unit: entry.PackageUnit}, food)
+	if err != nil || packageGrams == 0 {
+		return 0, fmt.Errorf(tr("unit %s is incompatible with package unit %s"), ingr.Unit, entry.PackageUnit)
+	}
+
+	return ingrGrams / packageGrams, nil
+}
+
+// calculateCost returns the cost of a list of ingredients and the ingredients that could not be included
+func calculateCost(ingredientList []Ingredient) RecipeCost {
+
+	cost := RecipeCost{Totals: map[string]float64{}, Warnings: []CostWarning{}}
+
+	for _, ingr := range ingredientList {
+
+		// Ingredients like "salt to taste" have no quantity and are not counted
+		if ingr.Quantity == 0 {
+			continue
+		}
+
+		entry, found := findPrice(ingr.Name)
+
+		if !found {
+			cost.Warnings = append(cost.Warnings, CostWarning{Ingredient: ingr, Reason: tr("no price")})
+			continue
+		}
+
+		packages, err := ingredientPackages(ingr, entry)
+
+		if err != nil {
+			cost.Warnings = append(cost.Warnings, CostWarning{Ingredient: ingr, Reason: err.Error()})
+			continue
+		}
+
+		cost.Totals[entry.Currency] += packages * entry.Price
+	}
+
+	return cost
+}
+
+// perPortion returns the cost of one default portion of the recipe
+func (cost RecipeCost) perPortion(portions int) RecipeCost {
+
+	if portions <= 0 {
+		return cost
+	}
+
+	portionCost := RecipeCost{Totals: map[string]float64{}, Warnings: cost.Warnings}
+	for currency, total := range cost.Totals {
+		portionCost.Totals[currency] = total / float64(portions)
+	}
+
+	return portionCost
+}
+
+func (cost RecipeCost) String() string {
+
+	currencies := []string{}
+	for currency := range cost.Totals {
+		currencies = append(currencies, currency)
+	}
+	sort.Strings(currencies)
+
+	parts := []string{}
+	for _, currency := range currencies {
+		parts = append(parts, formatPrice(cost.Totals[currency], currency))
+	}
+
+	return strings.Join(parts, " + ")
+}
+
+func formatPrice(amount float64, currency string) string {
+	return formatNumber(amount, 2) + " " + currency
+}
+
+// portionCostSortKey returns the cost of one portion used for sorting results.
+// Recipes without a cost or with prices in several currencies cannot be compared.
+func portionCostSortKey(recipe Recipe) (float64, bool) {
+
+	cost := calculateCost(expandIngredients(recipe.Ingredients)).perPortion(recipe.DefaultPortions)
+
+	if len(cost.Totals) != 1 {
+		return 0, false
+	}
+
+	for _, total := range cost.Totals {
+		return total, true
+	}
+
+	return 0, false
+}
+
+// costSummary returns a short text with the cost of one portion for the result list
+func costSummary(recipe Recipe) string {
+
+	cost := calculateCost(expandIngredients(recipe.Ingredients))
+
+	if len(cost.Totals) == 0 {
+		return ""
+	}
+
+	summary := tr("Cost: ") + cost.perPortion(recipe.DefaultPortions).String() + tr("/portion")
+	if len(cost.Warnings) != 0 {
+		summary += tr(" (incomplete)")
+	}
+
+	return summary
+}
+
+// sortByCost orders recipes by the cost of one portion, with recipes without a comparable cost last
+func sortByCost(recipes []Recipe, descending bool) {
+
+	type sortedRecipe struct {
+		recipe Recipe
+		cost   float64
+		known  bool
+	}
+
+	// Costs are calculated once per recipe, not in every comparison
+	sorted := make([]sortedRecipe, len(recipes))
+	for j, recipe := range recipes {
+		cost, known := portionCostSortKey(recipe)
+		sorted[j] = sortedRecipe{recipe: recipe, cost: cost, known: known}
+	}
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+
+		if sorted[i].known != sorted[j].known {
+			return sorted[i].known
+		}
+
+		if descending {
+			return sorted[i].cost > sorted[j].cost
+		}
+
+		return sorted[i].cost < sorted[j].cost
+	})
+
+	for j := range sorted {
+		recipes[j] = sorted[j].recipe
+	}
+}
+
+// createCostPanel shows total and per portion cost of a recipe with a list of ingredients that are not included.
+// Dialogs are shown over parent. onChanged is called when the price list changes.
+func createCostPanel(recipe Recipe, parent fyne.Window, onChanged func()) fyne.CanvasObject {
+
+	cost := calculateCost(recipe.Ingredients)
+
+	costTitle := newHeading(tr("Cost:"), 16)
+
+	panel := container.NewVBox(costTitle)
+
+	if len(cost.Totals) == 0 {
+		panel.Add(widget.NewLabel(tr("No prices for this recipe's ingredients.")))
+
+	} else {
+		panel.Add(widget.NewLabel(tr("Total: ") + cost.String()))
+
+		if recipe.DefaultPortions > 0 {
+			panel.Add(widget.NewLabel(tr("Per portion: ") + cost.perPortion(recipe.DefaultPortions).String()))
+		}
+	}
+
+	// Ingredients missing from the calculation
+	for _, warning := range cost.Warnings {
+
+		ingr := warning.Ingredient
+
+		warningLabel := widget.NewLabel(tr("Not included: ") + ingr.Name + " (" + warning.Reason + ")")
+		warningLabel.Wrapping = fyne.TextWrapWord
+
+		entry, found := findPrice(ingr.Name)
+		if !found {
+			entry = PriceEntry{Ingredient: normalizeFoodName(ingr.Name), PackageSize: ingr.Quantity, PackageUnit: ingr.Unit, Currency: config.defaultCurrency}
+		}
+
+		priceButton := widget.NewButtonWithIcon(tr("Set price"), theme.DocumentCreateIcon(), func() { showPriceEntryDialog(entry, parent, onChanged) })
+
+		panel.Add(container.NewBorder(nil, nil, nil, priceButton, warningLabel))
+	}
+
+	priceListButton := widget.NewButtonWithIcon(tr("Price list"), theme.ListIcon(), func() { showPriceListDialog(parent, onChanged) })
+	panel.Add(container.NewHBox(priceListButton))
+
+	return panel
+}
+
+// showPriceEntryDialog adds or changes the price of an ingredient
+func showPriceEntryDialog(entry PriceEntry, parent fyne.Window, onSaved func()) {
+
+	originalIngredient := entry.Ingredient
+
+	ingredientEntry := &widget.Entry{Text: entry.Ingredient, PlaceHolder: tr("Ingredient")}
+	sizeEntry := &widget.Entry{PlaceHolder: tr("Package size")}
+	unitEntry := &widget.Entry{Text: entry.PackageUnit, PlaceHolder: tr("Unit")}
+	priceEntry := &widget.Entry{PlaceHolder: tr("Price")}
+	currencyEntry := &widget.Entry{Text: entry.Currency, PlaceHolder: tr("Currency")}
+
+	if entry.PackageSize != 0 {
+		sizeEntry.SetText(formatNumber(entry.PackageSize, -1))
+	}
+
+	if entry.Price != 0 {
+		priceEntry.SetText(formatNumber(entry.Price, -1))
+	}
+
+	sizeEntry.Validator = validation.NewRegexp(`^[0-9]+([.,][0-9]+)?$`, tr("Value has to be a number."))
+	priceEntry.Validator = validation.NewRegexp(`^[0-9]+([.,][0-9]+)?$`, tr("Value has to be a number."))
+
+	formItems := []*widget.FormItem{
+		widget.NewFormItem(tr("Ingredient"), ingredientEntry),
+		widget.NewFormItem(tr("Package size"), container.NewGridWithColumns(2, sizeEntry, unitEntry)),
+		widget.NewFormItem(tr("Price"), container.NewGridWithColumns(2, priceEntry, currencyEntry)),
+	}
+
+	dialog.ShowForm(tr("Ingredient price"), tr("Save"), tr("Cancel"), formItems, func(confirmed bool) {
+
+		name := normalizeFoodName(ingredientEntry.Text)
+
+		if !confirmed || len(name) == 0 {
+			return
+		}
+
+		if _, _, known := unitAmount(1, unitEntry.Text); !known {
+			dialog.NewInformation(tr("Error"), fmt.Sprintf(tr("Unit %s is unknown."), unitEntry.Text), parent).Show()
+			return
+		}
+
+		size, _ := parseNumber(sizeEntry.Text)
+		price, _ := parseNumber(priceEntry.Text)
+
+		currency := strings.ToUpper(strings.TrimSpace(currencyEntry.Text))
+		if len(currency) == 0 {
+			currency = config.defaultCurrency
+		}
+
+		// Renaming replaces the original entry
+		priceList = slices.DeleteFunc(priceList, func(existing PriceEntry) bool {
+			return existing.Ingredient == originalIngredient || existing.Ingredient == name
+		})
+
+		priceList = append(priceList, PriceEntry{
+			Ingredient:  name,
+			PackageSize: size,
+			PackageUnit: strings.TrimSpace(unitEntry.Text),
+			Price:       price,
+			Currency:    currency,
+		})
+
+		if err := savePriceList(); err != nil {
+			dialog.NewError(err, parent).Show()
+		}
+
+		onSaved()
+
+	}, parent)
+}
+
+// showPriceListDialog lists all ingredient prices and allows adding, changing and removing them
+func showPriceListDialog(parent fyne.Window, onChanged func()) {
+
+	if priceList == nil {
+		loadPriceList()
+	}
+
+	priceRows := container.NewVBox()
+
+	var refreshRows func()
+	var priceDialog dialog.Dialog
+
+	refreshRows = func() {
+
+		priceRows.RemoveAll()
+
+		for _, entry := range priceList {
+
+			entry := entry
+
+			entryText := entry.Ingredient + ": " + formatPrice(entry.Price, entry.Currency) + " / " + formatNumber(entry.PackageSize, -1) + " " + entry.PackageUnit
+
+			editButton := &widget.Button{Icon: theme.DocumentCreateIcon(), OnTapped: func() {
+				priceDialog.Hide()
+				showPriceEntryDialog(entry, parent, onChanged)
+			}}
+
+			removeButton := &widget.Button{Icon: theme.DeleteIcon(), OnTapped: func() {
+
+				priceList = slices.DeleteFunc(priceList, func(existing PriceEntry) bool { return existing.Ingredient == entry.Ingredient })
+
+				if err := savePriceList(); err != nil {
+					dialog.NewError(err, parent).Show()
+				}
+
+				refreshRows()
+				onChanged()
+			}}
+
+			priceRows.Add(container.NewBorder(nil, nil, nil, container.NewHBox(editButton, removeButton), widget.NewLabel(entryText)))
+		}
+
+		priceRows.Refresh()
+	}
+
+	refreshRows()
+
+	addButton := widget.NewButtonWithIcon(tr("Add price"), theme.ContentAddIcon(), func() {
+		priceDialog.Hide()
+		showPriceEntryDialog(PriceEntry{Currency: config.defaultCurrency}, parent, onChanged)
+	})
+
+	priceScroll := container.NewVScroll(priceRows)
+	priceScroll.SetMinSize(fyne.NewSize(400, 300))
+
+	priceDialog = dialog.NewCustom(tr("Price list"), tr("Close"), container.NewBorder(nil, addButton, nil, nil, priceScroll), parent)
+	priceDialog.Show()
+}
+
+// costSortOptions maps result sort options to values of currentQuery["sort"]
+var costSortOptions = map[string]string{
+	"Default order":              "",
+	"Cost per portion - lowest":  "cost",
+	"Cost per portion - highest": "-cost",
+}
+
+// createSortSelect creates a select that reorders current results
+func createSortSelect() *widget.Select {
+
+	options := []string{"Default order", "Cost per portion - lowest", "Cost per portion - highest"}
+
+	// Options are shown translated, values are looked up by their English text
+	displayed := []string{}
+	translated := map[string]string{}
+	for _, option := range options {
+		displayed = append(displayed, tr(option))
+		translated[tr(option)] = option
+	}
+
+	sortSelect := widget.NewSelect(displayed, nil)
+
+	for option, value := range costSortOptions {
+		if value == currentQuery["sort"] {
+			sortSelect.SetSelected(tr(option))
+		}
+	}
+
+	sortSelect.OnChanged = func(selected string) {
+
+		currentQuery["sort"] = costSortOptions[translated[selected]]
+		refreshCurrentResults()
+	}
+
+	return sortSelect
+}
+
+// getSortedResults returns one page of results ordered by cost.
+// Cost is calculated locally, so all matching recipes are fetched and sorted before the page is cut out.
+// Only the fetched recipes are counted, so there are no pages beyond them.
+func getSortedResults(query map[string]string, offset int, perPage int) (results []Recipe, totalCount int) {
+
+	allResults, totalCount := getQueryResults(query, 0, costSortLimit)
+
+	sortByCost(allResults, query["sort"] == "-cost")
+
+	totalCount = int(math.Min(float64(totalCount), float64(len(allResults))))
+
+	if offset >= totalCount {
+		return []Recipe{}, totalCount
+	}
+
+	end := int(math.Min(float64(offset+perPage), float64(totalCount)))
+
+	return allResults[offset:end], totalCount
+}
+
+// createSortLimitNote tells the user that only some of the results were sorted by cost, or returns nil if all were
+func createSortLimitNote() fyne.CanvasObject {
+
+	if len(currentQuery["sort"]) == 0 || currentCount < costSortLimit {
+		return nil
+	}
+
+	return widget.NewLabel(fmt.Sprintf(tr("Only the first %d results are sorted by cost."), costSortLimit))
+}
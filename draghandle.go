@@ -0,0 +1,49 @@
+package main
+
+import (
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/driver/desktop"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+// DragHandle is an icon that can be dragged up and down to move the row it belongs to
+type DragHandle struct {
+	widget.Icon
+
+	// OnDropped is called with the vertical distance the handle was dragged
+	OnDropped func(offset float32)
+
+	offset float32
+}
+
+func newDragHandle(onDropped func(offset float32)) *DragHandle {
+
+	handle := &DragHandle{OnDropped: onDropped}
+	handle.Resource = theme.MenuIcon()
+	handle.ExtendBaseWidget(handle)
+
+	return handle
+}
+
+func (h *DragHandle) Dragged(event *fyne.DragEvent) {
+	h.offset += event.Dragged.DY
+}
+
+func (h *DragHandle) DragEnd() {
+
+	offset := h.offset
+	h.offset = 0
+
+	if h.OnDropped != nil {
+		h.OnDropped(offset)
+	}
+}
+
+func (h *DragHandle) Cursor() desktop.Cursor {
+	return desktop.VResizeCursor
+}
+
+func (h *DragHandle) MinSize() fyne.Size {
+	return fyne.NewSize(theme.IconInlineSize()+theme.Padding()*2, theme.IconInlineSize())
+}
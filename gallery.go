@@ -0,0 +1,99 @@
+package main
+
+import (
+	"fmt"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+// Horizontal drag distance that switches to the next or previous image
+const swipeDistance = 50
+
+// Gallery shows one image at a time; other images are reached by swiping or with the arrow buttons
+type Gallery struct {
+	widget.BaseWidget
+
+	images     [][]byte
+	current    int
+	dragOffset float32
+
+	image          *canvas.Image
+	counterLabel   *widget.Label
+	previousButton *widget.Button
+	nextButton     *widget.Button
+}
+
+func newGallery(images [][]byte, minSize fyne.Size) *Gallery {
+
+	gallery := &Gallery{images: images}
+	gallery.ExtendBaseWidget(gallery)
+
+	gallery.image = &canvas.Image{FillMode: canvas.ImageFillContain}
+	gallery.image.SetMinSize(minSize)
+
+	gallery.counterLabel = widget.NewLabel("")
+	gallery.previousButton = &widget.Button{Icon: theme.NavigateBackIcon(), OnTapped: func() { gallery.show(gallery.current - 1) }}
+	gallery.nextButton = &widget.Button{Icon: theme.NavigateNextIcon(), OnTapped: func() { gallery.show(gallery.current + 1) }}
+
+	gallery.show(0)
+
+	return gallery
+}
+
+// show displays the image at index, if it exists
+func (g *Gallery) show(index int) {
+
+	if index < 0 || index >= len(g.images) {
+		return
+	}
+
+	g.current = index
+	g.image.Resource = fyne.NewStaticResource(fmt.Sprint("gallery", index), g.images[index])
+	g.image.Refresh()
+
+	g.counterLabel.SetText(fmt.Sprint(index+1) + " / " + fmt.Sprint(len(g.images)))
+
+	g.previousButton.Enable()
+	if index == 0 {
+		g.previousButton.Disable()
+	}
+
+	g.nextButton.Enable()
+	if index == len(g.images)-1 {
+		g.nextButton.Disable()
+	}
+}
+
+func (g *Gallery) Dragged(event *fyne.DragEvent) {
+	g.dragOffset += event.Dragged.DX
+}
+
+// DragEnd switches images when the gallery is swiped far enough
+func (g *Gallery) DragEnd() {
+
+	if g.dragOffset < -swipeDistance {
+		g.show(g.current + 1)
+
+	} else if g.dragOffset > swipeDistance {
+		g.show(g.current - 1)
+	}
+
+	g.dragOffset = 0
+}
+
+func (g *Gallery) CreateRenderer() fyne.WidgetRenderer {
+
+	// Navigation is only needed with more than one image
+	if len(g.images) < 2 {
+		return widget.NewSimpleRenderer(g.image)
+	}
+
+	navigation := container.NewHBox(layout.NewSpacer(), g.previousButton, g.counterLabel, g.nextButton, layout.NewSpacer())
+
+	return widget.NewSimpleRenderer(container.NewBorder(nil, navigation, nil, nil, g.image))
+}
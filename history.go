@@ -0,0 +1,181 @@
+package main
+
+import (
+	"math"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	"golang.org/x/exp/maps"
+)
+
+// Screen is a view of the app that can be shown again with Back and Forward
+type Screen struct {
+	// "home", "results", "recipe" or "entry"
+	Kind string
+
+	// Query, page and title of the results the screen shows or was opened from
+	Query map[string]string
+	Page  int
+	Title string
+
+	// Recipe shown in details or edited; entry mode is "new" or "edit"
+	RecipeId string
+	Mode     string
+}
+
+// Screens visited before and after the current one
+var backHistory []Screen
+var forwardHistory []Screen
+var currentScreen *Screen
+
+// Set while a screen from history is shown again, so it is not added to history once more
+var restoringScreen bool
+
+// Enables the displayed Back and Forward buttons when there is a screen to go to
+var updateHistoryButtons = func() {}
+
+func sameScreen(a Screen, b Screen) bool {
+	return a.Kind == b.Kind && a.Page == b.Page && a.Title == b.Title && a.RecipeId == b.RecipeId && a.Mode == b.Mode && maps.Equal(a.Query, b.Query)
+}
+
+// visitScreen adds a newly displayed screen to history. Redisplaying the current screen, e.g. after a rating, is not a new visit.
+func visitScreen(screen Screen) {
+
+	screen.Query = maps.Clone(screen.Query)
+
+	defer updateHistoryButtons()
+
+	if restoringScreen {
+		currentScreen = &screen
+		return
+	}
+
+	if currentScreen != nil && sameScreen(*currentScreen, screen) {
+		return
+	}
+
+	if currentScreen != nil {
+		backHistory = append(backHistory, *currentScreen)
+	}
+
+	forwardHistory = []Screen{}
+	currentScreen = &screen
+}
+
+func goBack() {
+
+	if len(backHistory) == 0 || currentScreen == nil {
+		return
+	}
+
+	forwardHistory = append(forwardHistory, *currentScreen)
+	showHistoryScreen(&backHistory)
+}
+
+func goForward() {
+
+	if len(forwardHistory) == 0 || currentScreen == nil {
+		return
+	}
+
+	backHistory = append(backHistory, *currentScreen)
+	showHistoryScreen(&forwardHistory)
+}
+
+// closeScreen goes back from a screen that should not be reopened with Forward, e.g. a saved recipe entry
+func closeScreen() {
+
+	if len(backHistory) == 0 {
+		currentScreen = nil
+		displayHomePage()
+		return
+	}
+
+	showHistoryScreen(&backHistory)
+}
+
+// showHistoryScreen removes the last screen from a history list and shows it
+func showHistoryScreen(history *[]Screen) {
+
+	screen := (*history)[len(*history)-1]
+	*history = (*history)[:len(*history)-1]
+
+	showScreen(screen)
+}
+
+// showScreen displays a screen again, running its query to get current results
+func showScreen(screen Screen) {
+
+	restoringScreen = true
+	defer func() { restoringScreen = false }()
+
+	currentQuery = maps.Clone(screen.Query)
+	currentPage = screen.Page
+
+	switch screen.Kind {
+	case "home":
+		displayHomePage()
+		return
+
+	case "entry":
+		recipe := Recipe{}
+		if screen.Mode == "edit" {
+			recipe, _ = findRecipe(screen.RecipeId)
+		}
+
+		recipeEntry(recipe, screen.Mode)
+		return
+	}
+
+	currentRecipes, currentCount = getCurrentResults(config.resultsPerPage * (screen.Page - 1))
+	allPages := int(math.Ceil(float64(currentCount) / float64(config.resultsPerPage)))
+
+	// Results displayed beside details only lose the details, keeping their scroll position
+	if screen.Kind == "results" && showsCurrentResults() {
+		visitScreen(screen)
+		currentResultsTitle = screen.Title
+		clearDetailPane()
+		resultsPane.Refresh()
+		return
+	}
+
+	// Recipes that are no longer on the page, e.g. after they were edited, show the results instead
+	if screen.Kind == "recipe" {
+		for j, recipe := range currentRecipes {
+			if recipe.Id == screen.RecipeId {
+				displayRecipeDetails(j, allPages, screen.Title)
+				return
+			}
+		}
+	}
+
+	displayResults(allPages, screen.Title)
+}
+
+// createHistoryButtons creates Back and Forward buttons that are enabled when there is a screen to go to.
+// They stay up to date while results are displayed beside details.
+func createHistoryButtons() fyne.CanvasObject {
+
+	backButton := &widget.Button{Icon: theme.NavigateBackIcon(), OnTapped: func() { goBack() }}
+	forwardButton := &widget.Button{Icon: theme.NavigateNextIcon(), OnTapped: func() { goForward() }}
+
+	updateHistoryButtons = func() {
+		enableButton(backButton, len(backHistory) != 0)
+		enableButton(forwardButton, len(forwardHistory) != 0)
+	}
+
+	updateHistoryButtons()
+
+	return container.NewHBox(backButton, forwardButton)
+}
+
+func enableButton(button *widget.Button, enabled bool) {
+
+	if enabled {
+		button.Enable()
+	} else {
+		button.Disable()
+	}
+}
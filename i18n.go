@@ -0,0 +1,297 @@
+package main
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Languages of the user interface. English texts are used as keys of the translation catalogs.
+const (
+	languageEnglish   = "en"
+	languageSlovenian = "sl"
+)
+
+var languageNames = map[string]string{
+	languageEnglish:   "English",
+	languageSlovenian: "Slovenščina",
+}
+
+// Languages that write numbers with a decimal comma
+var decimalCommaLanguages = map[string]bool{
+	languageSlovenian: true,
+}
+
+// Translation catalogs: language -> English text -> translated text
+var catalogs = map[string]map[string]string{
+	languageSlovenian: {
+		// Login and MongoDB settings
+		"Welcome to MealTime!":                            "Dobrodošli v MealTime!",
+		"Welcome to MealTime! Please configure your app:": "Dobrodošli v MealTime! Nastavite aplikacijo:",
+		"Change MongoDB settings:":                        "Spremeni nastavitve MongoDB:",
+		"MongoDB App ID":                                  "ID aplikacije MongoDB",
+		"E-mail":                                          "E-pošta",
+		"Password":                                        "Geslo",
+		"Database name":                                   "Ime podatkovne baze",
+		"Collection name":                                 "Ime zbirke",
+		"App succesfully configured, you can now log in.": "Aplikacija je nastavljena, zdaj se lahko prijavite.",
+		"Login failed - wrong credentials!":               "Prijava ni uspela - napačni podatki za prijavo!",
+		"Login failed - App not found!":                   "Prijava ni uspela - aplikacije ni mogoče najti!",
+		"Login failed - database/collection not found!":   "Prijava ni uspela - baze ali zbirke ni mogoče najti!",
+		"Login failed - unknown error!":                   "Prijava ni uspela - neznana napaka!",
+		"Change settings":                                 "Spremeni nastavitve",
+		"Login":                                           "Prijava",
+		"Wrong password!":                                 "Napačno geslo!",
+
+		// Navigation and results
+		"Add new recipe":       "Dodaj nov recept",
+		"Search for recipe...": "Poišči recept ...",
+		"Everything":           "Vse",
+		"Ingredients":          "Sestavine",
+		"Allergens":            "Alergeni",
+		"All recipes":          "Vsi recepti",
+		"Favourites":           "Priljubljeni",
+		"Not cooked recently":  "Dolgo nekuhani",
+		"By category":          "Po kategoriji",
+		"By main ingredient":   "Po glavni sestavini",
+		"By ingredient":        "Po sestavini",
+		"By country":           "Po državi",
+		"By tag":               "Po oznaki",
+		"Collections":          "Zbirke",
+		"Results for: ":        "Rezultati za: ",
+		"Edit collection":      "Uredi zbirko",
+		"Show more":            "Pokaži več",
+		"Page %d of %d":        "Stran %d od %d",
+		"Page":                 "Stran",
+		"Go":                   "Pojdi",
+
+		// Recipe details
+		"Edit recipe":        "Uredi recept",
+		"Back":               "Nazaj",
+		"Add to collection":  "Dodaj v zbirko",
+		"Substitutes":        "Nadomestki",
+		"Ingredients:":       "Sestavine:",
+		"Preparation:":       "Priprava:",
+		"Category: ":         "Kategorija: ",
+		"%d min":             "%d min",
+		"%d portions":        "Porcije: %d",
+		"Select a recipe":    "Izberite recept",
+		"Open in new window": "Odpri v novem oknu",
+
+		// Allergens, collections and ratings
+		"Vegetarian":                            "Vegetarijansko",
+		"Vegan":                                 "Vegansko",
+		"Contains: ":                            "Vsebuje: ",
+		"gluten":                                "gluten",
+		"dairy":                                 "mlečni izdelki",
+		"egg":                                   "jajca",
+		"nuts":                                  "oreščki",
+		"peanuts":                               "arašidi",
+		"fish":                                  "ribe",
+		"shellfish":                             "lupinarji",
+		"soy":                                   "soja",
+		"sesame":                                "sezam",
+		"celery":                                "zelena",
+		"mustard":                               "gorčica",
+		"meat":                                  "meso",
+		"honey":                                 "med",
+		"Hide recipes containing:":              "Skrij recepte, ki vsebujejo:",
+		"Edit dictionary":                       "Uredi slovar",
+		"Apply":                                 "Uporabi",
+		"Allergen dictionary":                   "Slovar alergenov",
+		"Ingredient words, separated by commas": "Besede sestavin, ločene z vejicami",
+		"Exceptions, separated by commas":       "Izjeme, ločene z vejicami",
+		"Contained in:":                         "Vsebujejo:",
+		"Except:":                               "Razen:",
+		"Collection":                            "Zbirka",
+		"Delete collection":                     "Izbriši zbirko",
+		"Delete collection %s?":                 "Izbrišem zbirko %s?",
+		"(unknown recipe)":                      "(neznan recept)",
+		"Favourite":                             "Priljubljeno",
+		"Rating: %s/5 (%d)":                     "Ocena: %s/5 (%d)",
+		"Last cooked: ":                         "Nazadnje kuhano: ",
+		"I made this":                           "Skuhano",
+		"Your rating:":                          "Vaša ocena:",
+		"Log cooking":                           "Zabeleži kuhanje",
+		"Date":                                  "Datum",
+		"Notes":                                 "Opombe",
+		"Date has to be in format YYYY-MM-DD.":  "Datum mora biti v obliki LLLL-MM-DD.",
+		"Tag":                                   "Oznaka",
+		"Add tag":                               "Dodaj oznako",
+		"All tags":                              "Vse oznake",
+		"Any tag":                               "Katera koli oznaka",
+
+		// Costs, nutrition and substitutions
+		"Cost:":         "Cena:",
+		"Cost: ":        "Cena: ",
+		"/portion":      "/porcijo",
+		" (incomplete)": " (nepopolno)",
+		"No prices for this recipe's ingredients.": "Za sestavine tega recepta ni cen.",
+		"Total: ":                       "Skupaj: ",
+		"Per portion: ":                 "Na porcijo: ",
+		"Not included: ":                "Ni vključeno: ",
+		"no price":                      "ni cene",
+		"package size of %s is not set": "velikost pakiranja za %s ni nastavljena",
+		"unit %s is unknown":            "enota %s ni znana",
+		"package unit %s is unknown":    "enota pakiranja %s ni znana",
+		"unit %s is incompatible with package unit %s":  "enota %s ni združljiva z enoto pakiranja %s",
+		"Only the first %d results are sorted by cost.": "Po ceni je razvrščenih le prvih %d rezultatov.",
+		"Set price":                            "Nastavi ceno",
+		"Price list":                           "Cenik",
+		"Add price":                            "Dodaj ceno",
+		"Ingredient price":                     "Cena sestavine",
+		"Package size":                         "Velikost pakiranja",
+		"Price":                                "Cena",
+		"Currency":                             "Valuta",
+		"Unit %s is unknown.":                  "Enota %s ni znana.",
+		"Default order":                        "Privzeti vrstni red",
+		"Cost per portion - lowest":            "Cena na porcijo - najnižja",
+		"Cost per portion - highest":           "Cena na porcijo - najvišja",
+		"Nutrition:":                           "Hranilna vrednost:",
+		"no matching food":                     "ni ustreznega živila",
+		"Link":                                 "Poveži",
+		"Link ingredient":                      "Poveži sestavino",
+		"Import food table":                    "Uvozi tabelo živil",
+		"Food":                                 "Živilo",
+		"Food %s is not in the food table.":    "Živila %s ni v tabeli živil.",
+		"%d foods imported.":                   "Uvoženih živil: %d",
+		"Food table has no %s column.":         "Tabela živil nima stolpca %s.",
+		"weight of one piece is unknown":       "teža enega kosa ni znana",
+		"unit %s cannot be converted to grams": "enote %s ni mogoče pretvoriti v grame",
+		"%d kcal | protein %s g | fat %s g | carbohydrates %s g | fibre %s g": "%d kcal | beljakovine %s g | maščobe %s g | ogljikovi hidrati %s g | vlaknine %s g",
+		"Use in recipe":           "Uporabi v receptu",
+		"Use original ingredient": "Uporabi izvirno sestavino",
+		"Substitutes for ":        "Nadomestki za ",
+		"instead of ":             "namesto ",
+		"Close":                   "Zapri",
+		"portions":                "porcij",
+		" (recipe not found)":     " (recepta ni mogoče najti)",
+
+		// Recipe entry
+		"Recipe title":                   "Naslov recepta",
+		"Preparation time [min]":         "Čas priprave [min]",
+		"Number of portions":             "Število porcij",
+		"Description":                    "Opis",
+		"Main ingredient":                "Glavna sestavina",
+		"Category":                       "Kategorija",
+		"Country":                        "Država",
+		"Field is required.":             "Polje je obvezno.",
+		"Value has to be a number.":      "Vrednost mora biti število.",
+		"Group name, e.g. For the sauce": "Ime skupine, npr. Za omako",
+		"Insert ingredient":              "Vstavi sestavino",
+		"Insert recipe":                  "Vstavi recept",
+		"Insert group":                   "Vstavi skupino",
+		"Move up":                        "Premakni gor",
+		"Move down":                      "Premakni dol",
+		"Delete":                         "Izbriši",
+		"Ingredient":                     "Sestavina",
+		"Ingredient %d":                  "Sestavina %d",
+		"Recipe":                         "Recept",
+		"Group":                          "Skupina",
+		"Quantity":                       "Količina",
+		"Unit":                           "Enota",
+		"Note":                           "Opomba",
+		"Submit":                         "Potrdi",
+		"Error":                          "Napaka",
+		"Recipe cannot contain itself: ": "Recept ne more vsebovati samega sebe: ",
+		"Add image":                      "Dodaj sliko",
+		"Cover":                          "Naslovna",
+		"Set as cover":                   "Nastavi za naslovno",
+		"Image cannot be added":          "Slike ni mogoče dodati",
+		"Rotate left":                    "Zavrti levo",
+		"Rotate right":                   "Zavrti desno",
+		"Crop left":                      "Obreži levo",
+		"Crop right":                     "Obreži desno",
+		"Crop top":                       "Obreži zgoraj",
+		"Crop bottom":                    "Obreži spodaj",
+		"Edit image":                     "Uredi sliko",
+		"The file is empty.":             "Datoteka je prazna.",
+		"The file is %s MB large. Images can have at most %d MB.":              "Datoteka je velika %s MB. Slike imajo lahko največ %d MB.",
+		"The file is not in a supported image format.":                         "Datoteka ni v podprtem formatu slike.",
+		"The image file is damaged or incomplete (%s).":                        "Datoteka slike je poškodovana ali nepopolna (%s).",
+		"The image has %d x %d pixels. Images can have at most %d megapixels.": "Slika ima %d x %d slikovnih pik. Slike imajo lahko največ %d milijonov slikovnih pik.",
+		"Supported formats are %s,\nup to %d MB and %d megapixels.":            "Podprti formati so %s,\ndo %d MB in %d milijonov slikovnih pik.",
+		"JPEG, PNG, GIF, BMP and WebP":                                         "JPEG, PNG, GIF, BMP in WebP",
+		"OK":                                                                   "V redu",
+		"Insert failed: ":                                                      "Dodajanje ni uspelo: ",
+		"Update failed: ":                                                      "Posodobitev ni uspela: ",
+		"Recipe succesfully added!":                                            "Recept je dodan.",
+		"Recipe succesfully updated!":                                          "Recept je posodobljen.",
+		"Discard changes?":                                                     "Zavrzi spremembe?",
+		"The recipe has changes that were not submitted.":                      "Recept ima spremembe, ki niso bile potrjene.",
+		"Portions":                          "Porcije",
+		"Portions (empty for whole recipe)": "Porcije (prazno za cel recept)",
+		"Add recipe as ingredient":          "Dodaj recept kot sestavino",
+		"Add":                               "Dodaj",
+		"Recipe %s does not exist.":         "Recept %s ne obstaja.",
+
+		// Command palette
+		"Go to":                         "Pojdi na",
+		"Type an action or a recipe...": "Vpišite dejanje ali recept ...",
+		"Action":                        "Dejanje",
+		"New recipe":                    "Nov recept",
+		"Search":                        "Iskanje",
+		"Forward":                       "Naprej",
+
+		// Preferences
+		"Preferences":      "Nastavitve",
+		"Save":             "Shrani",
+		"Cancel":           "Prekliči",
+		"Image size (px)":  "Velikost slike (px)",
+		"JPEG quality":     "Kakovost JPEG",
+		"Results per page": "Rezultatov na stran",
+		"Window width":     "Širina okna",
+		"Window height":    "Višina okna",
+		"Theme":            "Tema",
+		"Units":            "Enote",
+		"Language":         "Jezik",
+		"Paging":           "Listanje",
+		"Pages":            "Po straneh",
+		"Infinite scroll":  "Neskončno drsenje",
+		"Dark":             "Temna",
+		"Light":            "Svetla",
+		"System":           "Sistemska",
+		"As written":       "Kot v receptu",
+		"Metric":           "Metrične",
+		"US customary":     "Ameriške",
+		"Value has to be a positive whole number.":      "Vrednost mora biti pozitivno celo število.",
+		"Value has to be a whole number from 1 to 100.": "Vrednost mora biti celo število od 1 do 100.",
+	},
+}
+
+// tr translates an English text to the chosen language. Texts missing from the catalog stay in English.
+func tr(text string) string {
+
+	if translated, exists := catalogs[config.language][text]; exists {
+		return translated
+	}
+
+	return text
+}
+
+// trList translates each of the texts
+func trList(texts []string) []string {
+
+	translated := []string{}
+	for _, text := range texts {
+		translated = append(translated, tr(text))
+	}
+
+	return translated
+}
+
+// formatNumber formats a number with the decimal separator of the chosen language
+func formatNumber(number float64, decimals int) string {
+
+	text := strconv.FormatFloat(number, 'f', decimals, 64)
+
+	if decimalCommaLanguages[config.language] {
+		text = strings.Replace(text, ".", ",", 1)
+	}
+
+	return text
+}
+
+// parseNumber reads a number written with a decimal point or a decimal comma
+func parseNumber(text string) (float64, error) {
+	return strconv.ParseFloat(strings.Replace(strings.TrimSpace(text), ",", ".", 1), 64)
+}
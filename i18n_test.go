@@ -0,0 +1,58 @@
+package main
+
+import "testing"
+
+func TestFormatNumber(t *testing.T) {
+
+	defer func(language string) { config.language = language }(config.language)
+
+	tests := []struct {
+		language string
+		number   float64
+		decimals int
+		text     string
+	}{
+		{languageEnglish, 1.5, 2, "1.50"},
+		{languageEnglish, 2, 0, "2"},
+		{languageSlovenian, 1.5, 2, "1,50"},
+		{languageSlovenian, 0.125, 3, "0,125"},
+	}
+
+	for _, test := range tests {
+
+		config.language = test.language
+
+		if text := formatNumber(test.number, test.decimals); text != test.text {
+			t.Errorf("formatNumber(%v, %d) in %s = %q, want %q", test.number, test.decimals, test.language, text, test.text)
+		}
+	}
+}
+
+func TestParseNumber(t *testing.T) {
+
+	tests := []struct {
+		text   string
+		number float64
+		valid  bool
+	}{
+		{"1.5", 1.5, true},
+		{"1,5", 1.5, true},
+		{" 250 ", 250, true},
+		{"1,5,0", 0, false},
+		{"one", 0, false},
+	}
+
+	for _, test := range tests {
+
+		number, err := parseNumber(test.text)
+
+		if (err == nil) != test.valid {
+			t.Errorf("parseNumber(%q) error = %v, want valid %v", test.text, err, test.valid)
+			continue
+		}
+
+		if test.valid && number != test.number {
+			t.Errorf("parseNumber(%q) = %v, want %v", test.text, number, test.number)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"container/list"
+	"sync"
+)
+
+// ImageCache keeps recently used images in memory and drops the least recently used ones when full
+type ImageCache struct {
+	capacity int
+	entries  map[string]*list.Element
+	order    *list.List
+	mutex    sync.Mutex
+}
+
+type imageCacheEntry struct {
+	key  string
+	data []byte
+}
+
+var imageCache *ImageCache
+
+func newImageCache(capacity int) *ImageCache {
+	return &ImageCache{capacity: capacity, entries: map[string]*list.Element{}, order: list.New()}
+}
+
+// get returns a cached image. Images known to be missing are cached as empty.
+func (c *ImageCache) get(key string) ([]byte, bool) {
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	element, exists := c.entries[key]
+
+	if !exists {
+		return []byte{}, false
+	}
+
+	c.order.MoveToFront(element)
+	return element.Value.(*imageCacheEntry).data, true
+}
+
+func (c *ImageCache) put(key string, data []byte) {
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if element, exists := c.entries[key]; exists {
+		element.Value.(*imageCacheEntry).data = data
+		c.order.MoveToFront(element)
+		return
+	}
+
+	c.entries[key] = c.order.PushFront(&imageCacheEntry{key: key, data: data})
+
+	if c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*imageCacheEntry).key)
+	}
+}
@@ -0,0 +1,277 @@
+package main
+
+import (
+	"encoding/binary"
+	"image"
+	"image/draw"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	"github.com/nfnt/resize"
+)
+
+// Large photos are scaled down to this size before editing, which keeps rotating and cropping fast
+const editorWorkingSizePx = 1600
+
+const editorPreviewSizePx = 400
+
+// EXIF orientation values: 1 is upright, 6 needs a clockwise and 8 a counterclockwise quarter turn, 3 is upside down
+// and 2, 4, 5 and 7 are the mirrored variants
+const (
+	orientationUpright          = 1
+	orientationRotatedClockwise = 6
+	orientationUpsideDown       = 3
+	orientationRotatedCounter   = 8
+)
+
+// CropMargins are parts of the image width or height cut away on each side
+type CropMargins struct {
+	Left   float64
+	Top    float64
+	Right  float64
+	Bottom float64
+}
+
+// exifOrientation returns the EXIF orientation of a JPEG image, or 1 if the image has none
+func exifOrientation(data []byte) int {
+
+	if len(data) < 4 || data[0] != 0xFF || data[1] != 0xD8 {
+		return orientationUpright
+	}
+
+	pos := 2
+
+	for pos+4 <= len(data) {
+
+		if data[pos] != 0xFF {
+			return orientationUpright
+		}
+
+		marker := data[pos+1]
+
+		// Image data starts after these markers, so there is no EXIF segment
+		if marker == 0xDA || marker == 0xD9 {
+			return orientationUpright
+		}
+
+		length := int(binary.BigEndian.Uint16(data[pos+2:]))
+
+		if length < 2 || pos+2+length > len(data) {
+			return orientationUpright
+		}
+
+		segment := data[pos+4 : pos+2+length]
+
+		if marker == 0xE1 && len(segment) >= 6 && string(segment[:6]) == "Exif\x00\x00" {
+			return tiffOrientation(segment[6:])
+		}
+
+		pos += 2 + length
+	}
+
+	return orientationUpright
+}
+
+// tiffOrientation reads the orientation tag from the first IFD of EXIF data in TIFF format
+func tiffOrientation(tiff []byte) int {
+
+	if len(tiff) < 8 {
+		return orientationUpright
+	}
+
+	var order binary.ByteOrder
+
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return orientationUpright
+	}
+
+	ifdOffset := int(order.Uint32(tiff[4:]))
+
+	if ifdOffset+2 > len(tiff) {
+		return orientationUpright
+	}
+
+	entryCount := int(order.Uint16(tiff[ifdOffset:]))
+
+	for j := 0; j < entryCount; j++ {
+
+		entry := ifdOffset + 2 + j*12
+
+		if entry+12 > len(tiff) {
+			break
+		}
+
+		if order.Uint16(tiff[entry:]) != 0x0112 {
+			continue
+		}
+
+		orientation := int(order.Uint16(tiff[entry+8:]))
+
+		if orientation < 1 || orientation > 8 {
+			return orientationUpright
+		}
+
+		return orientation
+	}
+
+	return orientationUpright
+}
+
+// orientImage turns and mirrors an image with the given EXIF orientation, so that it is upright
+func orientImage(img image.Image, orientation int) image.Image {
+
+	if orientation == orientationUpright {
+		return img
+	}
+
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+
+	// Orientations from 5 on swap width and height
+	oriented := image.NewRGBA(image.Rect(0, 0, width, height))
+	if orientation >= 5 {
+		oriented = image.NewRGBA(image.Rect(0, 0, height, width))
+	}
+
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+
+			var dx, dy int
+
+			switch orientation {
+			case 2:
+				dx, dy = width-1-x, y
+			case 3:
+				dx, dy = width-1-x, height-1-y
+			case 4:
+				dx, dy = x, height-1-y
+			case 5:
+				dx, dy = y, x
+			case 6:
+				dx, dy = height-1-y, x
+			case 7:
+				dx, dy = height-1-y, width-1-x
+			case 8:
+				dx, dy = y, width-1-x
+			default:
+				dx, dy = x, y
+			}
+
+			oriented.Set(dx, dy, img.At(bounds.Min.X+x, bounds.Min.Y+y))
+		}
+	}
+
+	return oriented
+}
+
+// editImage turns an image by quarter turns clockwise and then crops it
+func editImage(img image.Image, quarterTurns int, margins CropMargins) image.Image {
+
+	orientations := []int{orientationUpright, orientationRotatedClockwise, orientationUpsideDown, orientationRotatedCounter}
+	img = orientImage(img, orientations[((quarterTurns%4)+4)%4])
+
+	if margins == (CropMargins{}) {
+		return img
+	}
+
+	bounds := img.Bounds()
+	width, height := float64(bounds.Dx()), float64(bounds.Dy())
+
+	cropRect := image.Rect(
+		bounds.Min.X+int(width*margins.Left),
+		bounds.Min.Y+int(height*margins.Top),
+		bounds.Max.X-int(width*margins.Right),
+		bounds.Max.Y-int(height*margins.Bottom),
+	)
+
+	if cropRect.Empty() {
+		return img
+	}
+
+	cropped := image.NewRGBA(image.Rect(0, 0, cropRect.Dx(), cropRect.Dy()))
+	draw.Draw(cropped, cropped.Bounds(), img, cropRect.Min, draw.Src)
+
+	return cropped
+}
+
+// prepareImage scales a decoded photo down to the working size and turns it upright according to its EXIF data
+func prepareImage(img image.Image, data []byte) image.Image {
+
+	bounds := img.Bounds()
+
+	if bounds.Dx() > editorWorkingSizePx || bounds.Dy() > editorWorkingSizePx {
+		img = resize.Thumbnail(editorWorkingSizePx, editorWorkingSizePx, img, resize.Lanczos3)
+	}
+
+	return orientImage(img, exifOrientation(data))
+}
+
+// showImageEditor lets the user rotate and crop an image before it is added to a recipe
+func showImageEditor(img image.Image, onDone func(image.Image)) {
+
+	quarterTurns := 0
+	margins := CropMargins{}
+
+	previewSource := resize.Thumbnail(editorPreviewSizePx, editorPreviewSizePx, img, resize.Lanczos3)
+
+	preview := canvas.NewImageFromImage(previewSource)
+	preview.FillMode = canvas.ImageFillContain
+	preview.SetMinSize(fyne.NewSize(300, 300))
+
+	updatePreview := func() {
+		preview.Image = editImage(previewSource, quarterTurns, margins)
+		preview.Refresh()
+	}
+
+	rotateLeftButton := widget.NewButtonWithIcon(tr("Rotate left"), theme.MediaReplayIcon(), func() {
+		quarterTurns--
+		updatePreview()
+	})
+
+	rotateRightButton := widget.NewButtonWithIcon(tr("Rotate right"), theme.ViewRefreshIcon(), func() {
+		quarterTurns++
+		updatePreview()
+	})
+
+	// Sliders cut up to 45 % of the image on each side
+	newMarginSlider := func(margin *float64) *widget.Slider {
+
+		slider := widget.NewSlider(0, 45)
+		slider.OnChanged = func(value float64) {
+			*margin = value / 100
+			updatePreview()
+		}
+
+		return slider
+	}
+
+	cropForm := widget.NewForm(
+		widget.NewFormItem(tr("Crop left"), newMarginSlider(&margins.Left)),
+		widget.NewFormItem(tr("Crop right"), newMarginSlider(&margins.Right)),
+		widget.NewFormItem(tr("Crop top"), newMarginSlider(&margins.Top)),
+		widget.NewFormItem(tr("Crop bottom"), newMarginSlider(&margins.Bottom)),
+	)
+
+	controls := container.NewVBox(container.NewHBox(layout.NewSpacer(), rotateLeftButton, rotateRightButton, layout.NewSpacer()), cropForm)
+
+	editorDialog := dialog.NewCustomConfirm(tr("Edit image"), tr("Add"), tr("Cancel"), container.NewBorder(nil, controls, nil, nil, preview), func(confirmed bool) {
+
+		if confirmed {
+			onDone(editImage(img, quarterTurns, margins))
+		}
+
+	}, mainWindow)
+
+	editorDialog.Resize(fyne.NewSize(500, 650))
+	editorDialog.Show()
+}
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"encoding/binary"
+	"image"
+	"image/color"
+	"testing"
+)
+
+// exifJPEG returns the start of a JPEG file with an EXIF segment that holds only the orientation tag
+func exifJPEG(order binary.ByteOrder, orientation int) []byte {
+
+	tiff := make([]byte, 8+2+12)
+
+	if order == binary.LittleEndian {
+		copy(tiff, "II")
+	} else {
+		copy(tiff, "MM")
+	}
+	order.PutUint16(tiff[2:], 42)
+	order.PutUint32(tiff[4:], 8)
+
+	order.PutUint16(tiff[8:], 1)
+	order.PutUint16(tiff[10:], 0x0112)
+	order.PutUint16(tiff[12:], 3)
+	order.PutUint32(tiff[14:], 1)
+	order.PutUint16(tiff[18:], uint16(orientation))
+
+	segment := append([]byte("Exif\x00\x00"), tiff...)
+
+	data := []byte{0xFF, 0xD8, 0xFF, 0xE1, 0, 0}
+	binary.BigEndian.PutUint16(data[4:], uint16(len(segment)+2))
+
+	return append(append(data, segment...), 0xFF, 0xDA)
+}
+
+func TestExifOrientation(t *testing.T) {
+
+	tests := []struct {
+		name        string
+		data        []byte
+		orientation int
+	}{
+		{"little endian", exifJPEG(binary.LittleEndian, 6), 6},
+		{"big endian", exifJPEG(binary.BigEndian, 8), 8},
+		{"invalid value", exifJPEG(binary.BigEndian, 9), orientationUpright},
+		{"without EXIF", []byte{0xFF, 0xD8, 0xFF, 0xDA}, orientationUpright},
+		{"not a JPEG", []byte("\x89PNG\r\n"), orientationUpright},
+		{"truncated", exifJPEG(binary.LittleEndian, 6)[:12], orientationUpright},
+	}
+
+	for _, test := range tests {
+		if orientation := exifOrientation(test.data); orientation != test.orientation {
+			t.Errorf("exifOrientation(%s) = %d, want %d", test.name, orientation, test.orientation)
+		}
+	}
+}
+
+func TestOrientImage(t *testing.T) {
+
+	red := color.RGBA{R: 255, A: 255}
+	blue := color.RGBA{B: 255, A: 255}
+
+	// Red on the left, blue on the right
+	img := image.NewRGBA(image.Rect(0, 0, 2, 1))
+	img.Set(0, 0, red)
+	img.Set(1, 0, blue)
+
+	tests := []struct {
+		orientation int
+		size        image.Point
+		redAt       image.Point
+		blueAt      image.Point
+	}{
+		{orientationUpright, image.Pt(2, 1), image.Pt(0, 0), image.Pt(1, 0)},
+		{orientationUpsideDown, image.Pt(2, 1), image.Pt(1, 0), image.Pt(0, 0)},
+		{orientationRotatedClockwise, image.Pt(1, 2), image.Pt(0, 0), image.Pt(0, 1)},
+		{orientationRotatedCounter, image.Pt(1, 2), image.Pt(0, 1), image.Pt(0, 0)},
+	}
+
+	for _, test := range tests {
+
+		oriented := orientImage(img, test.orientation)
+
+		if size := oriented.Bounds().Size(); size != test.size {
+			t.Errorf("orientImage(%d) size = %v, want %v", test.orientation, size, test.size)
+			continue
+		}
+
+		if oriented.At(test.redAt.X, test.redAt.Y) != color.Color(red) || oriented.At(test.blueAt.X, test.blueAt.Y) != color.Color(blue) {
+			t.Errorf("orientImage(%d) has red at %v and blue at %v, want them at %v and %v", test.orientation,
+				findColor(oriented, red), findColor(oriented, blue), test.redAt, test.blueAt)
+		}
+	}
+}
+
+func findColor(img image.Image, c color.RGBA) image.Point {
+
+	bounds := img.Bounds()
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			if img.At(x, y) == color.Color(c) {
+				return image.Pt(x, y)
+			}
+		}
+	}
+
+	return image.Pt(-1, -1)
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+
+	// Decoders of supported image formats; JPEG is registered where images are encoded
+	_ "image/gif"
+	_ "image/png"
+
+	_ "golang.org/x/image/bmp"
+	_ "golang.org/x/image/webp"
+)
+
+// File extensions offered in the file dialog when adding images
+var imageExtensions = []string{".jpg", ".jpeg", ".png", ".gif", ".bmp", ".webp"}
+
+const supportedImageFormats = "JPEG, PNG, GIF, BMP and WebP"
+
+// Larger files and images are refused before decoding, as decoding them would use too much memory
+const (
+	maximumImageFileSizeMB = 25
+	maximumImageMegapixels = 50
+)
+
+// validateImage checks size and format of an image file before it is decoded
+func validateImage(data []byte) error {
+
+	if len(data) == 0 {
+		return errors.New(tr("The file is empty."))
+	}
+
+	if len(data) > maximumImageFileSizeMB*1024*1024 {
+		return fmt.Errorf(tr("The file is %s MB large. Images can have at most %d MB."), formatNumber(float64(len(data))/1024/1024, 1), maximumImageFileSizeMB)
+	}
+
+	imageConfig, _, err := image.DecodeConfig(bytes.NewReader(data))
+
+	if err == image.ErrFormat {
+		return errors.New(tr("The file is not in a supported image format."))
+	}
+
+	if err != nil {
+		return fmt.Errorf(tr("The image file is damaged or incomplete (%s)."), err.Error())
+	}
+
+	if imageConfig.Width*imageConfig.Height > maximumImageMegapixels*1000*1000 {
+		return fmt.Errorf(tr("The image has %d x %d pixels. Images can have at most %d megapixels."), imageConfig.Width, imageConfig.Height, maximumImageMegapixels)
+	}
+
+	return nil
+}
+
+// imageErrorMessage describes why an image cannot be added and which images are supported
+func imageErrorMessage(err error) string {
+	return err.Error() + "\n\n" + fmt.Sprintf(tr("Supported formats are %s,\nup to %d MB and %d megapixels."), tr(supportedImageFormats), maximumImageFileSizeMB, maximumImageMegapixels)
+}
@@ -0,0 +1,268 @@
+package main
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"image"
+	"image/jpeg"
+
+	"fyne.io/fyne/v2/dialog"
+	"github.com/nfnt/resize"
+)
+
+// StoredImage is a recipe image in the images collection. Images are keyed by the hash of their content,
+// so the same photo used in several recipes is stored once.
+type StoredImage struct {
+	Hash string `json:"_id"`
+	Data []byte `json:"data"`
+}
+
+// Images are stored in a separate MongoDB collection next to the recipes
+func imagesCollectionName() string {
+	return credentials["collection"] + "_images"
+}
+
+func imageHash(data []byte) string {
+
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// saveImage stores an image unless an identical one is already stored and returns its hash
+func saveImage(data []byte) (string, error) {
+
+	hash := imageHash(data)
+
+	body := map[string]interface{}{
+		"collection": imagesCollectionName(),
+		"filter":     map[string]string{"_id": hash},
+		"update":     map[string]interface{}{"$setOnInsert": map[string][]byte{"data": data}},
+		"upsert":     true,
+	}
+
+	if err := callDataAPI("updateOne", body, nil); err != nil {
+		return "", err
+	}
+
+	return hash, nil
+}
+
+// getImages returns stored images by hash and shows an error dialog if they cannot be loaded
+func getImages(hashes []string) map[string][]byte {
+
+	images, err := loadImages(hashes)
+
+	if err != nil {
+		errorDialog := dialog.NewError(err, mainWindow)
+		errorDialog.Show()
+	}
+
+	return images
+}
+
+// loadImages returns stored images by hash, from the image cache if possible
+func loadImages(hashes []string) (map[string][]byte, error) {
+
+	images := map[string][]byte{}
+	missingHashes := []string{}
+
+	for _, hash := range hashes {
+		if data, cached := imageCache.get(hash); cached {
+			images[hash] = data
+		} else {
+			missingHashes = append(missingHashes, hash)
+		}
+	}
+
+	if len(missingHashes) == 0 {
+		return images, nil
+	}
+
+	body := map[string]interface{}{
+		"collection": imagesCollectionName(),
+		"filter":     map[string]interface{}{"_id": map[string][]string{"$in": missingHashes}},
+	}
+
+	var response struct {
+		Documents []StoredImage
+	}
+
+	if err := callDataAPI("find", body, &response); err != nil {
+		return images, err
+	}
+
+	for _, storedImage := range response.Documents {
+		images[storedImage.Hash] = storedImage.Data
+		imageCache.put(storedImage.Hash, storedImage.Data)
+	}
+
+	return images, nil
+}
+
+// getLegacyImage returns the image stored in the document of a recipe saved before images were stored separately
+func getLegacyImage(recipeId string) []byte {
+
+	cacheKey := "recipe:" + recipeId
+
+	if data, cached := imageCache.get(cacheKey); cached {
+		return data
+	}
+
+	matchStage := pipelineStage{"$match": map[string]interface{}{"_id": map[string]string{"$oid": recipeId}}}
+	projectStage := pipelineStage{"$project": map[string]int{"image": 1}}
+
+	var response struct {
+		Documents []Recipe
+	}
+
+	if err := aggregate([]pipelineStage{matchStage, projectStage}, &response); err != nil || len(response.Documents) == 0 {
+		return []byte{}
+	}
+
+	// Recipes without an image are cached too, so they are not requested again
+	imageCache.put(cacheKey, response.Documents[0].Image)
+
+	return response.Documents[0].Image
+}
+
+// isLegacyImageRecipe tells if a recipe was saved before images were stored separately; such recipes have no image list
+func isLegacyImageRecipe(recipe Recipe) bool {
+	return recipe.Images == nil && len(recipe.Id) != 0
+}
+
+// recipeImages returns all images of a recipe, cover first
+func recipeImages(recipe Recipe) [][]byte {
+
+	if isLegacyImageRecipe(recipe) {
+
+		data := recipe.Image
+		if len(data) == 0 {
+			data = getLegacyImage(recipe.Id)
+		}
+
+		if len(data) == 0 {
+			return [][]byte{}
+		}
+
+		return [][]byte{data}
+	}
+
+	storedImages := getImages(recipe.Images)
+
+	images := [][]byte{}
+	for _, hash := range recipe.Images {
+		if data, exists := storedImages[hash]; exists {
+			images = append(images, data)
+		}
+	}
+
+	return images
+}
+
+// listImage returns the image shown in result lists: the thumbnail, or the full cover image for recipes without one.
+// Errors are ignored, as the list then keeps showing the placeholder.
+func listImage(recipe Recipe) []byte {
+
+	if len(recipe.Thumbnail) != 0 {
+		return recipe.Thumbnail
+	}
+
+	if isLegacyImageRecipe(recipe) {
+		return getLegacyImage(recipe.Id)
+	}
+
+	if len(recipe.Images) == 0 {
+		return []byte{}
+	}
+
+	images, _ := loadImages(recipe.Images[:1])
+	return images[recipe.Images[0]]
+}
+
+// createThumbnail scales an image down to the thumbnail size and encodes it as JPEG
+func createThumbnail(img image.Image) []byte {
+
+	img = resize.Thumbnail(config.thumbnailSizePx, config.thumbnailSizePx, img, resize.Lanczos3)
+
+	thumbnailBuffer := new(bytes.Buffer)
+	jpeg.Encode(thumbnailBuffer, img, &jpeg.Options{Quality: config.jpegQuality})
+
+	return thumbnailBuffer.Bytes()
+}
+
+// thumbnailOf creates a thumbnail of an encoded image
+func thumbnailOf(data []byte) []byte {
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+
+	if err != nil {
+		return []byte{}
+	}
+
+	return createThumbnail(img)
+}
+
+// saveRecipeImages stores images of a recipe and returns their hashes in the same order
+func saveRecipeImages(images [][]byte) ([]string, error) {
+
+	hashes := []string{}
+
+	for _, data := range images {
+
+		hash, err := saveImage(data)
+		if err != nil {
+			return []string{}, err
+		}
+
+		imageCache.put(hash, data)
+		hashes = append(hashes, hash)
+	}
+
+	return hashes, nil
+}
+
+// deleteUnusedImages removes the given images from the images collection unless a recipe still references them.
+// Images are shared between recipes by hash, so all recipes are checked, not only the one that was saved.
+func deleteUnusedImages(hashes []string) error {
+
+	if len(hashes) == 0 {
+		return nil
+	}
+
+	matchStage := pipelineStage{"$match": map[string]interface{}{"images": map[string][]string{"$in": hashes}}}
+	projectStage := pipelineStage{"$project": map[string]int{"images": 1}}
+
+	var response struct {
+		Documents []Recipe
+	}
+
+	if err := aggregate([]pipelineStage{matchStage, projectStage}, &response); err != nil {
+		return err
+	}
+
+	usedHashes := map[string]bool{}
+	for _, recipe := range response.Documents {
+		for _, hash := range recipe.Images {
+			usedHashes[hash] = true
+		}
+	}
+
+	unusedHashes := []string{}
+	for _, hash := range hashes {
+		if !usedHashes[hash] {
+			unusedHashes = append(unusedHashes, hash)
+		}
+	}
+
+	if len(unusedHashes) == 0 {
+		return nil
+	}
+
+	body := map[string]interface{}{
+		"collection": imagesCollectionName(),
+		"filter":     map[string]interface{}{"_id": map[string][]string{"$in": unusedHashes}},
+	}
+
+	return callDataAPI("deleteMany", body, nil)
+}
@@ -17,13 +17,18 @@ import (
 var mainApp fyne.App
 var mainWindow fyne.Window
 var navTree *widget.Tree
-var searchBar *widget.Entry
+var searchBar *SuggestionEntry
+var searchModeSelect *widget.Select
+var searchPanel *fyne.Container
 var credentials map[string]string
 var isMobile bool
 
 var ingredients []string
 var categories []string
 var countries []string
+var ingredientNames []string
+var tags []TagCount
+var recipeCollections []RecipeCollection
 var newRecipeButton *widget.Button
 
 // Current results
@@ -31,12 +36,35 @@ var currentRecipes []Recipe
 var currentCount int
 var currentQuery map[string]string
 var currentPage int
+var currentResultsTitle string
 
 type Config struct {
 	maximumImageSizePx   uint
 	resultsPerPage       int
 	desktopDefaultWidth  float32
 	desktopDefaultHeight float32
+
+	// Recipes not cooked in this many days are listed under "Not cooked recently"
+	notCookedRecentlyDays int
+
+	// Currency offered for new price list entries
+	defaultCurrency string
+
+	// Size of thumbnails shown in result lists and number of images kept in memory
+	thumbnailSizePx uint
+	imageCacheSize  int
+
+	// Quality of saved JPEG images, from 1 to 100
+	jpegQuality int
+
+	// Theme, unit system ingredient quantities are shown in and language of the user interface
+	theme      string
+	unitSystem string
+	language   string
+
+	// Results shown as a list or card grid, split into pages or loaded while scrolling
+	resultsView   string
+	resultsPaging string
 }
 
 var config Config
@@ -45,10 +73,12 @@ func main() {
 
 	// Load config
 	setConfig()
+	imageCache = newImageCache(config.imageCacheSize)
 
 	// General visual settings
 	mainApp = app.NewWithID("MealTimeApp")
-	mainApp.Settings().SetTheme(theme.DarkTheme())
+	loadPreferences()
+	applyTheme()
 	icon, _ := fyne.LoadResourceFromPath("resources/icon.png")
 	mainApp.SetIcon(icon)
 
@@ -56,6 +86,9 @@ func main() {
 	mainWindow.Resize(fyne.NewSize(config.desktopDefaultWidth, config.desktopDefaultHeight))
 	mainWindow.CenterOnScreen()
 
+	// Recipe windows are closed with the main window
+	mainWindow.SetMaster()
+
 	// Determine device
 	device := fyne.CurrentDevice()
 	isMobile = device.IsMobile()
@@ -67,7 +100,7 @@ func main() {
 		displaySettingsPage("new")
 
 	} else {
-		displayLoginPage("Welcome to MealTime!")
+		displayLoginPage(tr("Welcome to MealTime!"))
 	}
 
 	mainWindow.Show()
@@ -79,11 +112,20 @@ func main() {
 func displayInitialPage() {
 
 	initializeNavigation()
+	addAppShortcuts()
+
+	displayHomePage()
+	mainWindow.Canvas().Focus(searchBar)
+}
+
+// displayHomePage shows the navigation on mobile and all recipes on desktop
+func displayHomePage() {
 
 	// mobile layout is different - no default display of all recipes
 	if isMobile == true {
-		mainWindow.SetContent(container.NewBorder(searchBar, newRecipeButton, nil, nil, navTree))
 		currentQuery = map[string]string{}
+		visitScreen(Screen{Kind: "home", Query: currentQuery})
+		mainWindow.SetContent(container.NewBorder(searchPanel, newRecipeButton, nil, nil, navTree))
 
 	} else {
 		currentQuery = map[string]string{"type": "query", "fieldName": "", "fieldValue": ""}
@@ -93,9 +135,7 @@ func displayInitialPage() {
 		allPages := int(math.Ceil(float64(currentCount) / float64(config.resultsPerPage)))
 
 		currentPage = 1
-		displayResults(allPages, "All recipes")
-		mainWindow.Canvas().Focus(searchBar)
-
+		displayResults(allPages, tr("All recipes"))
 	}
 
 }
@@ -106,17 +146,17 @@ func displaySettingsPage(mode string) {
 
 	// mode can be "edit" or "new"
 	if mode == "new" {
-		welcomeLabel = widget.NewLabel("Welcome to MealTime! Please configure your app:")
+		welcomeLabel = widget.NewLabel(tr("Welcome to MealTime! Please configure your app:"))
 
 	} else {
-		welcomeLabel = widget.NewLabel("Change MongoDB settings:")
+		welcomeLabel = widget.NewLabel(tr("Change MongoDB settings:"))
 	}
 
-	appIdEntry := &widget.Entry{PlaceHolder: "MongoDB App ID"}
-	emailEntry := &widget.Entry{PlaceHolder: "E-mail"}
-	passwordEntry := &widget.Entry{PlaceHolder: "Password", Password: true}
-	dbEntry := &widget.Entry{PlaceHolder: "Database name"}
-	collEntry := &widget.Entry{PlaceHolder: "Collection name"}
+	appIdEntry := &widget.Entry{PlaceHolder: tr("MongoDB App ID")}
+	emailEntry := &widget.Entry{PlaceHolder: tr("E-mail")}
+	passwordEntry := &widget.Entry{PlaceHolder: tr("Password"), Password: true}
+	dbEntry := &widget.Entry{PlaceHolder: tr("Database name")}
+	collEntry := &widget.Entry{PlaceHolder: tr("Collection name")}
 
 	// Prefill for edit mode
 	if mode == "edit" {
@@ -128,15 +168,15 @@ func displaySettingsPage(mode string) {
 	}
 
 	// Field validators
-	appIdEntry.Validator = validation.NewRegexp(`.+`, "Field is required.")
-	emailEntry.Validator = validation.NewRegexp(`.+`, "Field is required.")
-	passwordEntry.Validator = validation.NewRegexp(`.+`, "Field is required.")
-	dbEntry.Validator = validation.NewRegexp(`.+`, "Field is required.")
-	collEntry.Validator = validation.NewRegexp(`.+`, "Field is required.")
+	appIdEntry.Validator = validation.NewRegexp(`.+`, tr("Field is required."))
+	emailEntry.Validator = validation.NewRegexp(`.+`, tr("Field is required."))
+	passwordEntry.Validator = validation.NewRegexp(`.+`, tr("Field is required."))
+	dbEntry.Validator = validation.NewRegexp(`.+`, tr("Field is required."))
+	collEntry.Validator = validation.NewRegexp(`.+`, tr("Field is required."))
 
 	formElements := []*widget.Entry{appIdEntry, emailEntry, passwordEntry, dbEntry, collEntry}
 
-	submitButton := &widget.Button{Text: "Submit", OnTapped: func() {}, Icon: theme.LoginIcon()}
+	submitButton := &widget.Button{Text: tr("Submit"), OnTapped: func() {}, Icon: theme.LoginIcon()}
 	submitButton.Disable()
 
 	// Set all fields to run validation on change
@@ -177,23 +217,23 @@ func displaySettingsPage(mode string) {
 			mainApp.Preferences().SetString("atlasAppEmail", emailEntry.Text)
 
 			// Proceed to login page
-			displayLoginPage("App succesfully configured, you can now log in.")
+			displayLoginPage(tr("App succesfully configured, you can now log in."))
 
 		} else {
 
 			responses := map[int]string{
-				401: "Login failed - wrong credentials!",
-				404: "Login failed - App not found!",
-				400: "Login failed - database/collection not found!",
+				401: tr("Login failed - wrong credentials!"),
+				404: tr("Login failed - App not found!"),
+				400: tr("Login failed - database/collection not found!"),
 			}
 
 			message, exists := responses[loginStatusCode]
 
 			if exists == false {
-				message = "Login failed - unknown error!"
+				message = tr("Login failed - unknown error!")
 			}
 
-			wrongCredentialsDialog := dialog.NewInformation("Error", message, mainWindow)
+			wrongCredentialsDialog := dialog.NewInformation(tr("Error"), message, mainWindow)
 			wrongCredentialsDialog.Show()
 		}
 
@@ -232,10 +272,10 @@ func displaySettingsPage(mode string) {
 func displayLoginPage(welcomeText string) {
 
 	welcomeLabel := widget.NewLabel(welcomeText)
-	passwordEntry := &widget.Entry{PlaceHolder: "Password", Password: true}
-	changeSettButton := &widget.Button{Text: "Change settings", Icon: theme.SettingsIcon(), OnTapped: func() { displaySettingsPage("edit") }}
+	passwordEntry := &widget.Entry{PlaceHolder: tr("Password"), Password: true}
+	changeSettButton := &widget.Button{Text: tr("Change settings"), Icon: theme.SettingsIcon(), OnTapped: func() { displaySettingsPage("edit") }}
 
-	loginButton := &widget.Button{Text: "Login", Icon: theme.LoginIcon()}
+	loginButton := &widget.Button{Text: tr("Login"), Icon: theme.LoginIcon()}
 	loginButton.OnTapped = func() {
 
 		storedHash := mainApp.Preferences().String("atlasAppPassword")
@@ -243,7 +283,7 @@ func displayLoginPage(welcomeText string) {
 
 		if err != nil {
 
-			wrongPasswordDialog := dialog.NewInformation("Error", "Wrong password!", mainWindow)
+			wrongPasswordDialog := dialog.NewInformation(tr("Error"), tr("Wrong password!"), mainWindow)
 			wrongPasswordDialog.Show()
 
 		} else {
@@ -288,3 +328,28 @@ func displayLoginPage(welcomeText string) {
 	mainWindow.Canvas().Focus(passwordEntry)
 
 }
+
+// runOnUIThread runs a function together with the event callbacks of a window, so it can change app state
+// and widgets of that window without racing them. Each window has its own event queue.
+func runOnUIThread(window fyne.Window, fn func()) {
+
+	// Fyne desktop and mobile windows process their events in order through an event queue
+	if eventWindow, ok := window.(interface{ QueueEvent(func()) }); ok {
+		eventWindow.QueueEvent(fn)
+		return
+	}
+
+	fn()
+}
+
+// windowForCanvas returns the window a canvas belongs to, the main window if it is not found
+func windowForCanvas(windowCanvas fyne.Canvas) fyne.Window {
+
+	for _, window := range mainApp.Driver().AllWindows() {
+		if window.Canvas() == windowCanvas {
+			return window
+		}
+	}
+
+	return mainWindow
+}
@@ -4,8 +4,9 @@ import (
 	"bytes"
 	"fmt"
 	"image"
-	"image/color"
+	"io/ioutil"
 	"math"
+	"reflect"
 	"strconv"
 	"strings"
 
@@ -17,25 +18,55 @@ import (
 	"fyne.io/fyne/v2/data/validation"
 	"fyne.io/fyne/v2/dialog"
 	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/storage"
 	"fyne.io/fyne/v2/theme"
 	"fyne.io/fyne/v2/widget"
 	"github.com/nfnt/resize"
 	"golang.org/x/exp/slices"
 )
 
+// Prefix of navigation tree node IDs in the "By ingredient" branch
+const ingredientNodePrefix = "ingredient:"
+
+// Main branches of the navigation tree. Their IDs are in English and translated when shown.
+var mainNodes = []widget.TreeNodeID{"All recipes", "Favourites", "Not cooked recently", "By category", "By main ingredient", "By ingredient", "By country", "By tag", "Collections"}
+
 func initializeNavigation() {
 
 	ingredients = getDistinctFieldValues("mainingredient")
 	categories = getDistinctFieldValues("category")
 	countries = getDistinctFieldValues("country")
+	ingredientNames = getDistinctArrayValues("ingredients", "name")
+	tags = getTagCounts()
+	recipeCollections = getCollections()
+
+	loadAllergens()
+
+	loadSearchIndex()
+
+	navTree = createNavigationTree(categories, ingredients, countries, ingredientNames)
 
-	navTree = createNavigationTree(categories, ingredients, countries)
+	createSearchPanel()
+}
+
+// createSearchPanel creates the search bar and buttons shown above results, in the chosen language
+func createSearchPanel() {
 
-	newRecipeButton = widget.NewButton("Add new recipe        ", func() { recipeEntry(Recipe{}, "new") })
+	newRecipeButton = widget.NewButton(tr("Add new recipe")+"        ", func() { recipeEntry(Recipe{}, "new") })
 	newRecipeButton.SetIcon(theme.ContentAddIcon())
 
-	searchBar = widget.NewEntry()
-	searchBar.SetPlaceHolder("Search for recipe...")
+	searchBar = newSuggestionEntry(searchSuggestions)
+	searchBar.SetPlaceHolder(tr("Search for recipe..."))
+
+	// Search in all fields or in ingredient names only
+	searchModeSelect = widget.NewSelect([]string{tr("Everything"), tr("Ingredients")}, func(string) {})
+	searchModeSelect.SetSelectedIndex(0)
+
+	allergenButton := widget.NewButtonWithIcon(tr("Allergens"), theme.VisibilityOffIcon(), func() { showAllergenFilterDialog() })
+
+	preferencesButton := widget.NewButtonWithIcon("", theme.SettingsIcon(), func() { showPreferencesDialog() })
+
+	searchPanel = container.NewBorder(nil, nil, nil, container.NewHBox(searchModeSelect, allergenButton, preferencesButton), searchBar)
 
 	searchBar.OnSubmitted = func(searchTerm string) {
 
@@ -45,10 +76,16 @@ func initializeNavigation() {
 
 		searchBar.SetText("")
 
-		currentQuery["type"] = "text"
+		if searchModeSelect.SelectedIndex() == 1 {
+			startQuery("ingredient")
+
+		} else {
+			startQuery("text")
+		}
+
 		currentQuery["searchTerm"] = searchTerm
 
-		currentRecipes, currentCount = getRecipesByText(currentQuery["searchTerm"], 0, config.resultsPerPage)
+		currentRecipes, currentCount = getCurrentResults(0)
 
 		allPages := int(math.Ceil(float64(currentCount) / float64(config.resultsPerPage)))
 
@@ -57,32 +94,143 @@ func initializeNavigation() {
 
 	}
 
+	// Categories, countries and main ingredients open their navigation tree node, other suggestions are searched for
+	searchBar.OnSuggestionChosen = func(suggestion Suggestion) {
+
+		switch suggestion.Kind {
+		case "Category", "Country", "Main ingredient":
+			searchBar.SetText("")
+			navTree.UnselectAll()
+			navTree.Select(suggestion.Text)
+
+		case "Ingredient":
+			searchBar.SetText("")
+			navTree.UnselectAll()
+			navTree.Select(ingredientNodePrefix + suggestion.Text)
+
+		case "Tag":
+			searchBar.SetText("")
+			navTree.UnselectAll()
+			navTree.Select(tagNodePrefix + suggestion.Text)
+
+		default:
+			searchBar.OnSubmitted(suggestion.Text)
+		}
+	}
+
+}
+
+// startQuery replaces the current query with a new one of a type, so nothing of the previous query, e.g. its order, is kept
+func startQuery(queryType string) {
+	currentQuery = map[string]string{"type": queryType}
 }
 
-func createNavigationTree(categ []string, ingr []string, countr []string) *widget.Tree {
+// getCurrentResults runs the current query or search and returns one page of results in the chosen order
+func getCurrentResults(offset int) (results []Recipe, totalCount int) {
+	return getResults(currentQuery, offset)
+}
+
+// getResults runs a query or search and returns one page of results in the chosen order.
+// It only reads the query it is given, so it can run in the background.
+func getResults(query map[string]string, offset int) (results []Recipe, totalCount int) {
+
+	if len(query["sort"]) != 0 {
+		return getSortedResults(query, offset, config.resultsPerPage)
+	}
+
+	return getQueryResults(query, offset, config.resultsPerPage)
+}
+
+// getQueryResults runs a query or search and returns one page of results in database order
+func getQueryResults(query map[string]string, offset int, perPage int) (results []Recipe, totalCount int) {
+
+	switch query["type"] {
+	case "text":
+		return getRecipesByText(query["searchTerm"], offset, perPage)
+
+	case "ingredient":
+		return getRecipesByIngredient(query["searchTerm"], offset, perPage)
+
+	case "tags":
+		return getRecipesByTags(queryTags(query), query["tagMode"] == "all", offset, perPage)
+
+	case "favourites":
+		return getRecipesByFilter(map[string]interface{}{"favouriteof": currentUser()}, offset, perPage)
+
+	case "notcooked":
+		return getRecipesByFilter(notCookedRecentlyFilter(), offset, perPage)
+
+	case "recipe":
+		results = getRecipesByIds([]string{query["recipeId"]})
+		return results, len(results)
+
+	case "collection":
+		recipeCollection, _ := findCollection(query["collectionId"])
+		return getRecipesInCollection(recipeCollection, offset, perPage)
+
+	default:
+		return getRecipes(query["fieldName"], query["fieldValue"], offset, perPage)
+	}
+}
+
+func createNavigationTree(categ []string, ingr []string, countr []string, ingrNames []string) *widget.Tree {
+
+	// Ingredient names can be the same as other values, so their node IDs are prefixed to stay unique
+	ingrNameNodes := []widget.TreeNodeID{}
+	for _, name := range ingrNames {
+		ingrNameNodes = append(ingrNameNodes, ingredientNodePrefix+name)
+	}
 
 	tree := widget.NewTree(
 		func(id widget.TreeNodeID) []widget.TreeNodeID {
 			switch id {
 			case "":
-				return []widget.TreeNodeID{"All recipes", "By category", "By main ingredient", "By country"}
+				return mainNodes
 			case "By category":
 				return categ
 			case "By main ingredient":
 				return ingr
+			case "By ingredient":
+				return ingrNameNodes
 			case "By country":
 				return countr
+			case "By tag":
+				return tagNodes()
+			case "Collections":
+				return collectionNodes()
 			}
 			return []string{}
 		},
 		func(id widget.TreeNodeID) bool {
-			return id == "" || id == "By category" || id == "By main ingredient" || id == "By country"
+			return id == "" || id == "By category" || id == "By main ingredient" || id == "By ingredient" || id == "By country" || id == "By tag" || id == "Collections"
 		},
 		func(branch bool) fyne.CanvasObject {
 			return widget.NewLabel("Node")
 		},
 		func(id widget.TreeNodeID, branch bool, o fyne.CanvasObject) {
-			o.(*widget.Label).SetText(id)
+			label := o.(*widget.Label)
+			label.TextStyle = fyne.TextStyle{}
+
+			if strings.HasPrefix(id, tagNodePrefix) {
+				tag := strings.TrimPrefix(id, tagNodePrefix)
+				label.TextStyle.Bold = isPopularTag(tag)
+				label.SetText(fmt.Sprintf("%s (%d)", tag, tagCount(tag)))
+				return
+			}
+
+			if strings.HasPrefix(id, collectionNodePrefix) {
+				recipeCollection, _ := findCollection(strings.TrimPrefix(id, collectionNodePrefix))
+				label.SetText(recipeCollection.Name)
+				return
+			}
+
+			// Only the main branches are translated, other nodes are values from recipes
+			if slices.Contains(mainNodes, id) {
+				label.SetText(tr(id))
+				return
+			}
+
+			label.SetText(strings.TrimPrefix(id, ingredientNodePrefix))
 		},
 	)
 
@@ -90,30 +238,73 @@ func createNavigationTree(categ []string, ingr []string, countr []string) *widge
 
 	tree.OnSelected = func(id string) {
 
+		if strings.HasPrefix(id, ingredientNodePrefix) {
+			startQuery("ingredient")
+			currentQuery["searchTerm"] = strings.TrimPrefix(id, ingredientNodePrefix)
+
+			currentRecipes, currentCount = getCurrentResults(0)
+
+			allPages := int(math.Ceil(float64(currentCount) / float64(config.resultsPerPage)))
+			currentPage = 1
+			displayResults(allPages, currentQuery["searchTerm"])
+			return
+		}
+
+		if strings.HasPrefix(id, tagNodePrefix) {
+			displayTagResults([]string{strings.TrimPrefix(id, tagNodePrefix)}, true)
+			return
+		}
+
+		if id == "Favourites" || id == "Not cooked recently" {
+
+			if id == "Favourites" {
+				startQuery("favourites")
+
+			} else {
+				startQuery("notcooked")
+			}
+
+			currentRecipes, currentCount = getCurrentResults(0)
+
+			allPages := int(math.Ceil(float64(currentCount) / float64(config.resultsPerPage)))
+			currentPage = 1
+			displayResults(allPages, tr(id))
+			return
+		}
+
+		if strings.HasPrefix(id, collectionNodePrefix) {
+			if recipeCollection, exists := findCollection(strings.TrimPrefix(id, collectionNodePrefix)); exists {
+				displayCollectionResults(recipeCollection)
+			}
+			return
+		}
+
+		fieldName := ""
+
 		if slices.Contains(categ, id) {
-			currentQuery["fieldName"] = "category"
-			currentQuery["fieldValue"] = id
+			fieldName = "category"
 
 		} else if slices.Contains(ingr, id) {
-			currentQuery["fieldName"] = "mainingredient"
-			currentQuery["fieldValue"] = id
+			fieldName = "mainingredient"
 
 		} else if slices.Contains(countr, id) {
-			currentQuery["fieldName"] = "country"
-			currentQuery["fieldValue"] = id
+			fieldName = "country"
 
-		} else if id == "All recipes" {
-			currentQuery["fieldName"] = ""
-			currentQuery["fieldValue"] = ""
-
-		} else {
+		} else if id != "All recipes" {
 			// no query if you click on main tree elements
 			return
 		}
 
-		currentQuery["type"] = "query"
+		startQuery("query")
+		currentQuery["fieldName"] = fieldName
+		currentQuery["fieldValue"] = id
+
+		if id == "All recipes" {
+			currentQuery["fieldValue"] = ""
+			id = tr(id)
+		}
 
-		currentRecipes, currentCount = getRecipes(currentQuery["fieldName"], currentQuery["fieldValue"], 0, config.resultsPerPage)
+		currentRecipes, currentCount = getCurrentResults(0)
 
 		allPages := int(math.Ceil(float64(currentCount) / float64(config.resultsPerPage)))
 		currentPage = 1
@@ -126,251 +317,533 @@ func createNavigationTree(categ []string, ingr []string, countr []string) *widge
 // displayResults creates a page with current query results
 func displayResults(allPages int, searchTerm string) {
 
-	resultsLabel := widget.NewLabel("Results for: " + searchTerm)
-	searchContainer := container.NewVBox(searchBar, widget.NewSeparator(), resultsLabel)
+	currentResultsTitle = searchTerm
 
-	// Placeholder image for recipes that don't have one
-	imagePlaceholder := canvas.NewImageFromResource(resourcePlaceholderJpg)
-	imagePlaceholder.FillMode = canvas.ImageFillContain
-	imagePlaceholder.SetMinSize(fyne.NewSize(50, 50))
+	visitScreen(Screen{Kind: "results", Query: currentQuery, Page: currentPage, Title: searchTerm})
 
-	// Result list
-	recipeList := widget.NewList(
-		func() int {
-			return len(currentRecipes)
-		},
-		func() fyne.CanvasObject {
-			return container.NewHBox(imagePlaceholder, widget.NewLabel("placeholder"))
-		},
-		func(i widget.ListItemID, o fyne.CanvasObject) {
-			o.(*fyne.Container).RemoveAll()
+	if isMobile {
+		mainWindow.SetContent(createResultsPane(allPages, searchTerm))
 
-			if len(currentRecipes[i].Image) == 0 {
-				o.(*fyne.Container).Add(imagePlaceholder)
+	} else {
+		showSplitView(createResultsPane(allPages, searchTerm))
+	}
+}
 
-			} else {
-				recipeImage := canvas.NewImageFromResource(fyne.NewStaticResource("img", currentRecipes[i].Image))
-				recipeImage.SetMinSize(fyne.NewSize(50, 50))
-				o.(*fyne.Container).Add(recipeImage)
+// createResultsPane creates the search panel, current query results and pager
+func createResultsPane(allPages int, searchTerm string) fyne.CanvasObject {
 
-			}
+	// Redisplay results after switching between the list and the card grid
+	viewToggle := createViewToggle(func() { displayResults(allPages, searchTerm) })
 
-			o.(*fyne.Container).Add(container.NewVBox(layout.NewSpacer(), widget.NewLabel(currentRecipes[i].Title), layout.NewSpacer()))
+	resultsLabel := widget.NewLabel(tr("Results for: ") + searchTerm)
+	searchContainer := container.NewVBox(searchPanel, widget.NewSeparator(), container.NewBorder(nil, nil, createHistoryButtons(), container.NewHBox(viewToggle, createSortSelect()), resultsLabel))
 
-		})
+	if sortLimitNote := createSortLimitNote(); sortLimitNote != nil {
+		searchContainer.Add(sortLimitNote)
+	}
 
-	recipeList.OnSelected = func(id widget.ListItemID) { displayRecipeDetails(id, allPages, searchTerm) }
+	if currentQuery["type"] == "tags" {
+		searchContainer.Add(createTagFilterBar())
+	}
 
-	// Pagination button placeholder that determines button width
-	pagButtonPlaceholder := fmt.Sprint(allPages) + " "
+	if currentQuery["type"] == "collection" {
+		if recipeCollection, exists := findCollection(currentQuery["collectionId"]); exists {
+			editCollectionButton := widget.NewButtonWithIcon(tr("Edit collection"), theme.DocumentCreateIcon(), func() { showEditCollectionDialog(recipeCollection) })
+			searchContainer.Add(container.NewHBox(editCollectionButton))
+		}
+	}
 
-	// Displays page numbers
-	paginationTable := widget.NewTable(
-		func() (int, int) {
-			return 1, allPages
-		},
-		func() fyne.CanvasObject {
-			return widget.NewButton(pagButtonPlaceholder, func() {})
-		},
-		func(i widget.TableCellID, o fyne.CanvasObject) {
+	openRecipe := func(id int) { displayRecipeDetails(id, allPages, searchTerm) }
 
-			o.(*widget.Button).SetText(fmt.Sprint(i.Col + 1))
+	var resultsView fyne.CanvasObject
+	if config.resultsView == resultsViewGrid {
+		resultsView = createRecipeGrid(openRecipe)
 
-			// Switch to another page
-			o.(*widget.Button).OnTapped = func() {
+	} else {
+		resultsView = createRecipeList(openRecipe)
+	}
 
-				// Check if this was a normal query or text search
-				if currentQuery["type"] == "query" {
-					currentRecipes, currentCount = getRecipes(currentQuery["fieldName"], currentQuery["fieldValue"], config.resultsPerPage*(i.Col), config.resultsPerPage)
+	// Infinite scroll needs no pager
+	bottomBar := container.NewMax()
+	if config.resultsPaging != resultsPagingScroll {
+		bottomBar.Add(createPager(allPages))
+	}
 
-				} else {
-					currentRecipes, currentCount = getRecipesByText(currentQuery["searchTerm"], config.resultsPerPage*(i.Col), config.resultsPerPage)
-				}
+	// Mobile layout has recipe list across whole screen and a way back to navigation
+	if isMobile {
+		homeButton := &widget.Button{Icon: theme.HomeIcon(), OnTapped: func() { displayHomePage() }}
+		bottomBar = container.NewBorder(nil, nil, nil, homeButton, bottomBar)
+	}
+
+	return container.NewBorder(searchContainer, bottomBar, nil, nil, resultsView)
+}
 
-				currentPage = i.Col + 1
-				displayResults(allPages, searchTerm)
+func displayRecipeDetails(id widget.ListItemID, allPages int, searchTerm string) {
+	chosenRecipe := currentRecipes[id]
 
-			}
+	visitScreen(Screen{Kind: "recipe", Query: currentQuery, Page: currentPage, Title: searchTerm, RecipeId: chosenRecipe.Id})
 
-			if i.Col+1 == currentPage {
-				o.(*widget.Button).Disable()
-			}
+	editRecipeButton := widget.NewButtonWithIcon(tr("Edit recipe"), theme.DocumentCreateIcon(), func() { recipeEntry(currentRecipes[id], "edit") })
+	backButton := widget.NewButtonWithIcon(tr("Back"), theme.NavigateBackIcon(), func() { goBack() })
+	addToCollectionButton := widget.NewButtonWithIcon(tr("Add to collection"), theme.FolderNewIcon(), func() { showAddToCollectionDialog(chosenRecipe) })
 
-		})
+	// Redisplay details after rating, favourite or cook log changes, also in the recipe's own window
+	onRated := func(updated Recipe) {
+		currentRecipes[id] = updated
+		updateRecipeWindow(updated)
+		displayRecipeDetails(id, allPages, searchTerm)
+	}
 
-	// Mobile layout has recipe list across whole screen
-	if isMobile {
-		backButton := &widget.Button{Icon: theme.NavigateBackIcon(), OnTapped: func() { mainWindow.SetContent(container.NewBorder(searchBar, newRecipeButton, nil, nil, navTree)) }}
+	detailsContainer := createRecipeDetails(chosenRecipe, mainWindow, onRated, func() { displayRecipeDetails(id, allPages, searchTerm) })
+
+	buttons := []fyne.CanvasObject{layout.NewSpacer(), backButton, editRecipeButton, addToCollectionButton}
 
-		paginationContainer := container.NewBorder(nil, nil, nil, backButton, paginationTable)
-		contentContainer := container.NewBorder(searchContainer, paginationContainer, nil, nil, recipeList)
-		mainWindow.SetContent(contentContainer)
+	// Desktop recipes can also be kept open beside the main window
+	if !isMobile {
+		buttons = append(buttons, createOpenWindowButton(chosenRecipe))
+	}
+
+	detailsContainer.Add(container.NewHBox(append(buttons, layout.NewSpacer())...))
+
+	if isMobile {
+		mainWindow.SetContent(container.NewVScroll(detailsContainer))
 
 	} else {
-		contentContainer := container.NewBorder(searchContainer, paginationTable, nil, nil, recipeList)
-		mainWindow.SetContent(container.NewBorder(nil, nil, container.NewBorder(nil, newRecipeButton, nil, nil, navTree), nil, contentContainer))
+		showInDetailPane(container.NewVScroll(detailsContainer), allPages, searchTerm)
 	}
 
 }
 
-func displayRecipeDetails(id widget.ListItemID, allPages int, searchTerm string) {
-	chosenRecipe := currentRecipes[id]
+// createRecipeDetails shows a recipe with its images, ingredients, nutrition, costs and preparation.
+// Dialogs are shown over parent, the window the details are in. Details are redisplayed with redisplay after the user changes them.
+func createRecipeDetails(chosenRecipe Recipe, parent fyne.Window, onRated func(Recipe), redisplay func()) *fyne.Container {
 
-	editRecipeButton := widget.NewButtonWithIcon("Edit recipe", theme.DocumentCreateIcon(), func() { recipeEntry(currentRecipes[id], "edit") })
-	backButton := widget.NewButtonWithIcon("Back", theme.NavigateBackIcon(), func() { displayResults(allPages, searchTerm) })
+	ratingPanel := createRatingPanel(chosenRecipe, parent, onRated)
 
 	descriptionLabel := widget.NewLabel(chosenRecipe.Description)
 	descriptionLabel.Wrapping = fyne.TextWrapWord
 
+	// Ingredients rescaled to the portions chosen by the user
+	portions := chosenRecipe.DefaultPortions
+	if chosenPortions, exists := displayedPortions[chosenRecipe.Id]; exists {
+		portions = chosenPortions
+	}
+	scaledRecipe := chosenRecipe.scaled(portions)
+
+	// Ingredients with substitutes chosen by the user replaced and other recipes expanded
+	displayedRecipe := expandedRecipe(withSubstitutions(scaledRecipe))
+
 	// Prepare ingredient list
 	ingredientTable := container.NewVBox()
-	for j, ingr := range chosenRecipe.Ingredients {
-		ingrText := ingr.Name
-		if ingr.Quantity != 0 {
+	previousGroup := ""
+	for j, ingr := range scaledRecipe.Ingredients {
 
-			// Check if integer
-			if math.Round(ingr.Quantity) == ingr.Quantity {
-				ingrText += " " + fmt.Sprint(int(ingr.Quantity))
+		// Section header where an ingredient group starts
+		if ingr.Group != previousGroup && len(ingr.Group) != 0 {
+			ingredientTable.Add(widget.NewLabelWithStyle(ingr.Group, fyne.TextAlignLeading, fyne.TextStyle{Bold: true}))
+		}
+		previousGroup = ingr.Group
 
-			} else {
-				ingrText += " " + strconv.FormatFloat(ingr.Quantity, 'f', 2, 64)
-			}
+		if len(ingr.RecipeId) != 0 {
+			ingredientTable.Add(createRecipeReference(ingr, j+1))
+			continue
+		}
 
+		ingrIndex := j
+		ingrLabel := widget.NewLabel(fmt.Sprint(j+1) + ". " + ingredientText(ingr))
+		ingrLabel.Wrapping = fyne.TextWrapWord
+
+		if _, exists := findSubstitution(ingr.Name); !exists {
+			ingredientTable.Add(ingrLabel)
+			continue
 		}
-		if len(ingr.Unit) != 0 {
-			ingrText += " " + ingr.Unit
+
+		substituteButton := widget.NewButtonWithIcon(tr("Substitutes"), theme.ViewRefreshIcon(), func() { showSubstitutionDialog(scaledRecipe, ingrIndex, parent, redisplay) })
+		substituteButton.Importance = widget.LowImportance
+
+		substitution, _ := findSubstitution(ingr.Name)
+
+		substituteIndex, applied := appliedSubstitutions[chosenRecipe.Id][ingr.Name]
+		if !applied || substituteIndex >= len(substitution.Substitutes) {
+			ingredientTable.Add(container.NewBorder(nil, nil, nil, substituteButton, ingrLabel))
+			continue
 		}
 
-		if len(ingr.Notes) != 0 && ingr.Notes != "/" {
-			ingrText += " (" + ingr.Notes + ")"
+		// Substituted ingredients are shown instead of the original one
+		replacementContainer := container.NewVBox()
+
+		for _, replacement := range substituteIngredients(ingr, substitution.Substitutes[substituteIndex]) {
+			replacement.Quantity = roundQuantity(replacement.Quantity)
+
+			replacementLabel := widget.NewLabel(fmt.Sprint(j+1) + ". " + ingredientText(replacement))
+			replacementLabel.TextStyle = fyne.TextStyle{Italic: true}
+			replacementLabel.Wrapping = fyne.TextWrapWord
+			replacementContainer.Add(replacementLabel)
 		}
 
-		ingrLabel := widget.NewLabel(fmt.Sprint(j+1) + ". " + ingrText)
-		ingrLabel.Wrapping = fyne.TextWrapWord
-		ingredientTable.Add(ingrLabel)
+		ingredientTable.Add(container.NewBorder(nil, nil, nil, substituteButton, replacementContainer))
 	}
 
-	// Displays recipe image if available
+	// Redisplay details after nutrition links or the food table change
+	nutritionPanel := createNutritionPanel(displayedRecipe, parent, redisplay)
+
+	// Redisplay details after the price list changes
+	costPanel := createCostPanel(displayedRecipe, parent, redisplay)
+
+	// Displays recipe images if available
 	imageContainer := container.NewMax()
-	if len(chosenRecipe.Image) != 0 {
-		imageRes := fyne.NewStaticResource(chosenRecipe.Id, chosenRecipe.Image)
-		canvasImage := canvas.NewImageFromResource(imageRes)
-		canvasImage.FillMode = canvas.ImageFillContain
-		canvasImage.SetMinSize(fyne.NewSize(200, 200))
-		imageContainer.Add(canvasImage)
+	if images := recipeImages(chosenRecipe); len(images) != 0 {
+		imageContainer.Add(newGallery(images, fyne.NewSize(200, 200)))
 	}
 
 	// Page layout
-	titleLabel := canvas.NewText(chosenRecipe.Title, color.White)
-	titleLabel.TextSize = 20
+	titleLabel := newHeading(chosenRecipe.Title, 20)
+	ingredientsTitle := newHeading(tr("Ingredients:"), 16)
+	preparationTitle := newHeading(tr("Preparation:"), 16)
 
-	ingredientsTitle := canvas.NewText("Ingredients:", color.White)
-	ingredientsTitle.TextSize = 16
-
-	preparationTitle := canvas.NewText("Preparation:", color.White)
-	preparationTitle.TextSize = 16
-
-	detailsContainer := container.NewVBox(
+	return container.NewVBox(
 		container.New(layout.NewCenterLayout(), titleLabel),
 		widget.NewLabel(""),
 		imageContainer,
-		container.New(layout.NewCenterLayout(), widget.NewLabel("Category: "+chosenRecipe.Category)),
-		container.NewHBox(layout.NewSpacer(), widget.NewLabel(fmt.Sprint(chosenRecipe.PrepTime)+" min"), widget.NewLabel("|"), widget.NewLabel(fmt.Sprint(chosenRecipe.DefaultPortions)+" portions"), layout.NewSpacer()),
+		container.New(layout.NewCenterLayout(), widget.NewLabel(tr("Category: ")+chosenRecipe.Category)),
+		container.New(layout.NewCenterLayout(), createAllergenBadges(chosenRecipe)),
+		container.NewHBox(layout.NewSpacer(), widget.NewLabel(fmt.Sprintf(tr("%d min"), chosenRecipe.PrepTime)), widget.NewLabel("|"), createPortionStepper(chosenRecipe.Id, portions, redisplay), layout.NewSpacer()),
+		ratingPanel,
 		ingredientsTitle,
 		ingredientTable,
+		nutritionPanel,
+		costPanel,
 		preparationTitle,
 		descriptionLabel,
-		container.NewHBox(layout.NewSpacer(), backButton, editRecipeButton, layout.NewSpacer()),
 	)
+}
 
-	if isMobile {
-		mainWindow.SetContent(container.NewVScroll(detailsContainer))
+// Numbers of portions chosen by the user in recipe details: recipe ID -> portions
+var displayedPortions = map[string]int{}
 
-	} else {
-		mainWindow.SetContent(container.NewBorder(nil, nil, container.NewBorder(nil, newRecipeButton, nil, nil, navTree), nil, container.NewVScroll(detailsContainer)))
+// scaled returns the recipe with ingredient quantities rescaled to a number of portions
+func (recipe Recipe) scaled(portions int) Recipe {
+
+	if recipe.DefaultPortions <= 0 || portions <= 0 || portions == recipe.DefaultPortions {
+		return recipe
 	}
 
+	recipe.Ingredients = scaledIngredients(recipe.Ingredients, float64(portions)/float64(recipe.DefaultPortions))
+	recipe.DefaultPortions = portions
+
+	return recipe
+}
+
+// createPortionStepper shows the number of portions with buttons that rescale the ingredients
+func createPortionStepper(recipeId string, portions int, onChanged func()) fyne.CanvasObject {
+
+	lessButton := &widget.Button{Icon: theme.ContentRemoveIcon(), OnTapped: func() {
+		displayedPortions[recipeId] = portions - 1
+		onChanged()
+	}}
+
+	moreButton := &widget.Button{Icon: theme.ContentAddIcon(), OnTapped: func() {
+		displayedPortions[recipeId] = portions + 1
+		onChanged()
+	}}
+
+	if portions <= 1 {
+		lessButton.Disable()
+	}
+
+	return container.NewHBox(lessButton, widget.NewLabel(fmt.Sprintf(tr("%d portions"), portions)), moreButton)
+}
+
+// ingredientText formats an ingredient as a line of the ingredient list, e.g. "flour 200 g (sifted)"
+func ingredientText(ingr Ingredient) string {
+
+	ingr = inUnitSystem(ingr, config.unitSystem)
+
+	ingrText := ingr.Name
+	if ingr.Quantity != 0 {
+
+		// Check if integer
+		if math.Round(ingr.Quantity) == ingr.Quantity {
+			ingrText += " " + fmt.Sprint(int(ingr.Quantity))
+
+		} else {
+			ingrText += " " + formatNumber(ingr.Quantity, 2)
+		}
+
+	}
+	if ingr.Unit == recipePortionsUnit {
+		// Unit of recipe references is stored in English
+		ingrText += " " + tr(recipePortionsUnit)
+
+	} else if len(ingr.Unit) != 0 {
+		ingrText += " " + ingr.Unit
+	}
+
+	if len(ingr.Notes) != 0 && ingr.Notes != "/" {
+		ingrText += " (" + ingr.Notes + ")"
+	}
+
+	return ingrText
 }
 
 // recipeEntry displays a page for adding a new recipe or editiing an existing one
 func recipeEntry(recipe Recipe, mode string) {
 
-	recipeImage := []byte{}
+	// Images of the recipe, cover first, and thumbnails created when images were added: image hash -> thumbnail
+	recipeImageList := [][]byte{}
+	recipeThumbnails := map[string][]byte{}
 
-	allPages := int(math.Ceil(float64(currentCount) / float64(config.resultsPerPage)))
-	backButton := widget.NewButtonWithIcon("Back", theme.NavigateBackIcon(), func() { displayResults(allPages, currentQuery["fieldValue"]) })
+	visitScreen(Screen{Kind: "entry", Query: currentQuery, Page: currentPage, Title: currentResultsTitle, RecipeId: recipe.Id, Mode: mode})
+
+	backButton := widget.NewButtonWithIcon(tr("Back"), theme.NavigateBackIcon(), func() { leaveRecipeEntry(goBack) })
 
 	// Entry fields
-	titleEntry := &widget.Entry{PlaceHolder: "Recipe title"}
-	prepEntry := &widget.Entry{PlaceHolder: "Preparation time [min]"}
-	portionEntry := &widget.Entry{PlaceHolder: "Number of portions"}
+	titleEntry := newFormEntry(tr("Recipe title"))
+	prepEntry := newFormEntry(tr("Preparation time [min]"))
+	portionEntry := newFormEntry(tr("Number of portions"))
 
-	descriptionEntry := widget.NewMultiLineEntry()
-	descriptionEntry.SetPlaceHolder("Description")
+	descriptionEntry := newFormEntry(tr("Description"))
+	descriptionEntry.MultiLine = true
+	descriptionEntry.Wrapping = fyne.TextTruncate
 	descriptionEntry.SetMinRowsVisible(5)
 
 	mainIngredientSelect := widget.NewSelectEntry(ingredients)
-	mainIngredientSelect.SetPlaceHolder("Main ingredient")
+	mainIngredientSelect.SetPlaceHolder(tr("Main ingredient"))
 
 	categorySelect := widget.NewSelectEntry(categories)
-	categorySelect.SetPlaceHolder("Category")
+	categorySelect.SetPlaceHolder(tr("Category"))
 
 	countrySelect := widget.NewSelectEntry(countries)
-	countrySelect.SetPlaceHolder("Country")
+	countrySelect.SetPlaceHolder(tr("Country"))
+
+	tagEditor, enteredTags := createTagEditor(recipe.Tags)
 
 	// Validators
-	titleEntry.Validator = validation.NewRegexp(`.+`, "Field is required.")
-	descriptionEntry.Validator = validation.NewRegexp(`.+`, "Field is required.")
-	prepEntry.Validator = validation.NewRegexp(`^[0-9]*[1-9][0-9]*$`, "Value has to be a number.")
-	portionEntry.Validator = validation.NewRegexp(`^[0-9]*[1-9][0-9]*$`, "Value has to be a number.")
-	mainIngredientSelect.Validator = validation.NewRegexp(`.+`, "Field is required.")
-	categorySelect.Validator = validation.NewRegexp(`.+`, "Field is required.")
-	countrySelect.Validator = validation.NewRegexp(`.+`, "Field is required.")
+	titleEntry.Validator = validation.NewRegexp(`.+`, tr("Field is required."))
+	descriptionEntry.Validator = validation.NewRegexp(`.+`, tr("Field is required."))
+	prepEntry.Validator = validation.NewRegexp(`^[0-9]*[1-9][0-9]*$`, tr("Value has to be a number."))
+	portionEntry.Validator = validation.NewRegexp(`^[0-9]*[1-9][0-9]*$`, tr("Value has to be a number."))
+	mainIngredientSelect.Validator = validation.NewRegexp(`.+`, tr("Field is required."))
+	categorySelect.Validator = validation.NewRegexp(`.+`, tr("Field is required."))
+	countrySelect.Validator = validation.NewRegexp(`.+`, tr("Field is required."))
+
+	// Values of ingredient fields in the order they are displayed.
+	// Group header rows hold only the group name entry; the ingredients below them belong to the group.
+	ingredientData := [][]*FormEntry{}
+	ingrContainer := container.NewVBox()
+
+	// Ingredients that reference other recipes: name entry -> recipe ID
+	ingredientRecipeIds := map[*FormEntry]string{}
 
-	// Create one ingredient row
-	name, qty, unit, note := createIngredientRow(1)
+	var recipeEntryContainer *fyne.Container
+	var refreshIngredientRows func()
 
-	// Store values of ingredient fields for later access
-	ingredientData := [][]*widget.Entry{}
-	ingredientData = append(ingredientData, []*widget.Entry{name, qty, unit, note})
+	newIngredientRow := func() []*FormEntry {
+		name, qty, unit, note := createIngredientRow()
+		return []*FormEntry{name, qty, unit, note}
+	}
 
-	ingredientEntry := container.NewHBox(name, qty, unit, note)
-	addIngrButton := &widget.Button{Icon: theme.ContentAddIcon()}
+	newGroupRow := func(groupName string) []*FormEntry {
+		groupEntry := newFormEntry(tr("Group name, e.g. For the sauce"))
+		groupEntry.Text = groupName
+		groupEntry.TextStyle = fyne.TextStyle{Bold: true}
+		return []*FormEntry{groupEntry}
+	}
 
-	ingrContainer := container.NewVBox(container.NewBorder(nil, nil, nil, addIngrButton, ingredientEntry))
+	// Reference rows have title and unit fixed until they are turned into ordinary ingredients
+	newReferenceRow := func(ingr Ingredient) []*FormEntry {
+		row := newIngredientRow()
+		row[0].Text, row[1].Text, row[2].Text, row[3].Text = ingr.Name, formatNumber(ingr.Quantity, -1), ingr.Unit, ingr.Notes
+		row[0].Disable()
+		row[2].Disable()
+		ingredientRecipeIds[row[0]] = ingr.RecipeId
+		return row
+	}
 
-	var recipeEntryContainer *fyne.Container
+	insertIngredientRow := func(position int, row []*FormEntry) {
+		ingredientData = slices.Insert(ingredientData, position, row)
+		refreshIngredientRows()
+	}
+
+	// Ingredients of a removed group join the group above
+	removeIngredientRow := func(position int) {
+		delete(ingredientRecipeIds, ingredientData[position][0])
+		ingredientData = slices.Delete(ingredientData, position, position+1)
+		refreshIngredientRows()
+	}
+
+	moveIngredientRow := func(from int, to int) {
+
+		if to < 0 {
+			to = 0
+		}
+
+		if to > len(ingredientData)-1 {
+			to = len(ingredientData) - 1
+		}
+
+		if from == to {
+			return
+		}
+
+		row := ingredientData[from]
+		ingredientData = slices.Delete(ingredientData, from, from+1)
+		ingredientData = slices.Insert(ingredientData, to, row)
+		refreshIngredientRows()
+	}
+
+	// Menu for inserting an ingredient, a recipe or a group at a position
+	insertMenu := func(position int) *fyne.Menu {
+		return fyne.NewMenu("",
+			fyne.NewMenuItem(tr("Insert ingredient"), func() { insertIngredientRow(position, newIngredientRow()) }),
+			fyne.NewMenuItem(tr("Insert recipe"), func() {
+				showRecipeReferenceDialog(recipe.Id, func(ingr Ingredient) { insertIngredientRow(position, newReferenceRow(ingr)) })
+			}),
+			fyne.NewMenuItem(tr("Insert group"), func() { insertIngredientRow(position, newGroupRow("")) }),
+		)
+	}
+
+	showMenuBelow := func(menu *fyne.Menu, button fyne.CanvasObject) {
+		position := fyne.CurrentApp().Driver().AbsolutePositionForObject(button).Add(fyne.NewPos(0, button.Size().Height))
+		widget.ShowPopUpMenuAtPosition(menu, mainWindow.Canvas(), position)
+	}
+
+	// Rebuilds displayed rows from ingredientData, so both always have the same order
+	refreshIngredientRows = func() {
+
+		ingrContainer.RemoveAll()
+		ingrNumber := 0
+
+		for j, row := range ingredientData {
+
+			position := j
+			rowButtons := container.NewHBox()
+
+			var rowContent fyne.CanvasObject = row[0]
+			if len(row) != 1 {
+				ingrNumber++
+				row[0].SetPlaceHolder(fmt.Sprintf(tr("Ingredient %d"), ingrNumber))
+				rowContent = container.NewHBox(row[0], row[1], row[2], row[3])
+			}
+
+			if _, isReference := ingredientRecipeIds[row[0]]; isReference {
+				rowButtons.Add(&widget.Button{Icon: theme.CancelIcon(), OnTapped: func() {
+					delete(ingredientRecipeIds, row[0])
+					row[0].Enable()
+					row[2].Enable()
+					refreshIngredientRows()
+				}})
+			}
+
+			if isMobile {
+				// Mobile screens have room for one button with all row operations
+				var menuButton *widget.Button
+				menuButton = &widget.Button{Icon: theme.MoreVerticalIcon(), OnTapped: func() {
+					menu := insertMenu(position + 1)
+					menu.Items = append([]*fyne.MenuItem{
+						fyne.NewMenuItem(tr("Move up"), func() { moveIngredientRow(position, position-1) }),
+						fyne.NewMenuItem(tr("Move down"), func() { moveIngredientRow(position, position+1) }),
+						fyne.NewMenuItem(tr("Delete"), func() { removeIngredientRow(position) }),
+						fyne.NewMenuItemSeparator(),
+					}, menu.Items...)
+					showMenuBelow(menu, menuButton)
+				}}
+				rowButtons.Add(menuButton)
+				ingrContainer.Add(container.NewBorder(nil, nil, nil, rowButtons, rowContent))
+				continue
+			}
+
+			upButton := &widget.Button{Icon: theme.MoveUpIcon(), OnTapped: func() { moveIngredientRow(position, position-1) }}
+			downButton := &widget.Button{Icon: theme.MoveDownIcon(), OnTapped: func() { moveIngredientRow(position, position+1) }}
+			deleteButton := &widget.Button{Icon: theme.DeleteIcon(), OnTapped: func() { removeIngredientRow(position) }}
+
+			var insertButton *widget.Button
+			insertButton = &widget.Button{Icon: theme.ContentAddIcon(), OnTapped: func() { showMenuBelow(insertMenu(position+1), insertButton) }}
+
+			if position == 0 {
+				upButton.Disable()
+			}
+
+			if position == len(ingredientData)-1 {
+				downButton.Disable()
+			}
+
+			rowButtons.Add(upButton)
+			rowButtons.Add(downButton)
+			rowButtons.Add(insertButton)
+			rowButtons.Add(deleteButton)
+
+			// Rows can also be dragged by the handle, by whole row heights
+			var rowContainer *fyne.Container
+			dragHandle := newDragHandle(func(offset float32) {
+				rowHeight := rowContainer.Size().Height + theme.Padding()
+				moveIngredientRow(position, position+int(math.Round(float64(offset/rowHeight))))
+			})
+
+			rowContainer = container.NewBorder(nil, nil, dragHandle, rowButtons, rowContent)
+			ingrContainer.Add(rowContainer)
+		}
+
+		ingrContainer.Refresh()
+
+		if recipeEntryContainer != nil {
+			recipeEntryContainer.Refresh()
+		}
+	}
+
+	// Buttons that append rows at the end of the ingredient list
+	addIngrButton := &widget.Button{Text: tr("Ingredient"), Icon: theme.ContentAddIcon(), OnTapped: func() {
+		insertIngredientRow(len(ingredientData), newIngredientRow())
+	}}
+
+	addRecipeButton := &widget.Button{Text: tr("Recipe"), Icon: theme.ContentAddIcon(), OnTapped: func() {
+		showRecipeReferenceDialog(recipe.Id, func(ingr Ingredient) { insertIngredientRow(len(ingredientData), newReferenceRow(ingr)) })
+	}}
+
+	addGroupButton := &widget.Button{Text: tr("Group"), Icon: theme.ContentAddIcon(), OnTapped: func() {
+		insertIngredientRow(len(ingredientData), newGroupRow(""))
+	}}
+
+	ingrButtons := container.NewHBox(addIngrButton, addRecipeButton, addGroupButton)
 
-	submitButton := &widget.Button{Text: "Submit", Icon: theme.ConfirmIcon(), OnTapped: func() {
+	// Recipe as entered in the form, without images
+	enteredRecipe := func() Recipe {
 
 		prepTime, _ := strconv.Atoi(prepEntry.Text)
 		DefaultPortions, _ := strconv.Atoi(portionEntry.Text)
 
 		// Create Ingredient objects
 		var ingredients []Ingredient
+		currentGroup := ""
 		for _, singleIngredient := range ingredientData {
 
+			if len(singleIngredient) == 1 {
+				currentGroup = strings.TrimSpace(singleIngredient[0].Text)
+				continue
+			}
+
 			ingrQtyErr := singleIngredient[1].Validate()
 
 			if len(strings.TrimSpace(singleIngredient[0].Text)) == 0 || ingrQtyErr != nil {
 				continue
 			}
 
-			newIngrQty, _ := strconv.ParseFloat(singleIngredient[1].Text, 64)
+			newIngrQty, _ := parseNumber(singleIngredient[1].Text)
 
 			newIngredient := Ingredient{
 				Name:     singleIngredient[0].Text,
 				Quantity: newIngrQty,
 				Unit:     singleIngredient[2].Text,
 				Notes:    singleIngredient[3].Text,
+				Group:    currentGroup,
+				RecipeId: ingredientRecipeIds[singleIngredient[0]],
 			}
 
 			ingredients = append(ingredients, newIngredient)
 
 		}
 
-		// Create Recipe object
-		newDocument := Recipe{
+		return Recipe{
 			Title:           titleEntry.Text,
 			Description:     descriptionEntry.Text,
 			Category:        categorySelect.Text,
@@ -379,45 +852,126 @@ func recipeEntry(recipe Recipe, mode string) {
 			PrepTime:        prepTime,
 			DefaultPortions: DefaultPortions,
 			Ingredients:     ingredients,
-			Image:           recipeImage,
+			Tags:            enteredTags(),
+		}
+	}
+
+	submitButton := &widget.Button{Text: tr("Submit"), Icon: theme.ConfirmIcon(), OnTapped: func() {
+
+		newDocument := enteredRecipe()
+
+		// A recipe cannot contain itself, directly or through other recipes.
+		// This is checked before images are stored, so a refused recipe leaves no images behind.
+		if cycle, found := findReferenceCycle(recipe.Id, newDocument.Ingredients); found {
+			cycleText := strings.Join(append([]string{titleEntry.Text}, cycle...), " -> ")
+			dialog.NewInformation(tr("Error"), tr("Recipe cannot contain itself: ")+cycleText, mainWindow).Show()
+			return
+		}
+
+		// Images are stored before the recipe that references them
+		imageHashes, err := saveRecipeImages(recipeImageList)
+
+		if err != nil {
+			errorDialog := dialog.NewError(err, mainWindow)
+			errorDialog.Show()
+			return
+		}
+
+		newDocument.Images = imageHashes
+
+		// Thumbnail of the cover for result lists; images that were already saved don't have one yet
+		if len(recipeImageList) != 0 {
+			thumbnail, exists := recipeThumbnails[imageHash(recipeImageList[0])]
+			if !exists {
+				thumbnail = thumbnailOf(recipeImageList[0])
+			}
+			newDocument.Thumbnail = thumbnail
 		}
 
 		var addUpdateOperation bool
+		savedId := recipe.Id
 
 		if mode == "new" {
-			addUpdateOperation = newDocument.addNewRecipe()
+			savedId, addUpdateOperation = newDocument.addNewRecipe()
 
 		} else {
 			addUpdateOperation = newDocument.updateRecipe(recipe.Id)
 		}
 
+		// Images removed from the recipe, or stored for a recipe that could not be saved, are deleted
+		// unless another recipe uses them
+		unusedImages := slices.DeleteFunc(slices.Clone(recipe.Images), func(hash string) bool { return slices.Contains(imageHashes, hash) })
+		if !addUpdateOperation {
+			unusedImages = slices.DeleteFunc(slices.Clone(imageHashes), func(hash string) bool { return slices.Contains(recipe.Images, hash) })
+		}
+
+		go deleteUnusedImages(unusedImages)
+
 		if addUpdateOperation == true {
-			allPages := int(math.Ceil(float64(currentCount) / float64(config.resultsPerPage)))
-			displayResults(allPages, currentQuery["fieldValue"])
+			updateSearchIndex(savedId)
+
+			// Counts of tags change with tags of new and edited recipes
+			if len(newDocument.Tags) != 0 || len(recipe.Tags) != 0 {
+				reloadTags()
+			}
+			updateSavedRecipeWindows(savedId)
+
+			// Return to the screen the entry was opened from, with the saved recipe reloaded
+			closeScreen()
 		}
 
 	}}
 
 	submitButton.Disable()
 
-	// Adds ingredient row
-	addIngrButton.OnTapped = func() {
+	submitRecipeEntry = func() {
+		if !submitButton.Disabled() {
+			submitButton.OnTapped()
+		}
+	}
 
-		name, qty, unit, note = createIngredientRow(len(ingrContainer.Objects) + 1)
-		ingredientData = append(ingredientData, []*widget.Entry{name, qty, unit, note})
-		extraIngredientEntry := container.NewHBox(name, qty, unit, note)
+	addImageButton := &widget.Button{Text: tr("Add image"), OnTapped: func() {}, Icon: theme.MediaPhotoIcon()}
+	imageStrip := container.NewHBox()
+	addImageContainer := container.NewBorder(nil, nil, container.NewVBox(layout.NewSpacer(), addImageButton, layout.NewSpacer()), nil, container.NewHScroll(imageStrip))
 
-		ingrContainer.Add(container.NewBorder(nil, nil, nil, nil, extraIngredientEntry))
-		ingrContainer.Refresh()
-		recipeEntryContainer.Refresh()
+	// Shows images with buttons for choosing the cover and removing images
+	var refreshImageStrip func()
 
-	}
+	refreshImageStrip = func() {
+
+		imageStrip.RemoveAll()
+
+		for j, imageData := range recipeImageList {
+
+			position := j
+
+			canvasImage := canvas.NewImageFromResource(fyne.NewStaticResource(fmt.Sprint("image", j), imageData))
+			canvasImage.FillMode = canvas.ImageFillContain
+			canvasImage.SetMinSize(fyne.NewSize(100, 100))
+
+			removeButton := &widget.Button{Icon: theme.DeleteIcon(), OnTapped: func() {
+				recipeImageList = slices.Delete(recipeImageList, position, position+1)
+				refreshImageStrip()
+			}}
+
+			var coverControl fyne.CanvasObject = widget.NewLabelWithStyle(tr("Cover"), fyne.TextAlignCenter, fyne.TextStyle{Bold: true})
+			if position != 0 {
+				coverControl = widget.NewButton(tr("Set as cover"), func() {
+					cover := recipeImageList[position]
+					recipeImageList = slices.Delete(recipeImageList, position, position+1)
+					recipeImageList = slices.Insert(recipeImageList, 0, cover)
+					refreshImageStrip()
+				})
+			}
+
+			imageStrip.Add(container.NewBorder(nil, container.NewBorder(nil, nil, nil, removeButton, coverControl), nil, nil, canvasImage))
+		}
 
-	addImageButton := &widget.Button{Text: "Add image", OnTapped: func() {}, Icon: theme.MediaPhotoIcon()}
-	addImageContainer := container.NewGridWithColumns(2, container.NewHBox(container.NewVBox(layout.NewSpacer(), addImageButton, layout.NewSpacer()), layout.NewSpacer()))
+		imageStrip.Refresh()
+	}
 
 	// All entries for easier validation
-	entryElements := []*widget.Entry{titleEntry, descriptionEntry, prepEntry, portionEntry, &categorySelect.Entry, &mainIngredientSelect.Entry}
+	entryElements := []*widget.Entry{&titleEntry.Entry, &descriptionEntry.Entry, &prepEntry.Entry, &portionEntry.Entry, &categorySelect.Entry, &mainIngredientSelect.Entry}
 
 	for _, elem := range entryElements {
 
@@ -447,13 +1001,32 @@ func recipeEntry(recipe Recipe, mode string) {
 			return
 		}
 
-		chosenImage, _, err := image.Decode(f)
+		defer f.Close()
+
+		// Image content is kept for reading EXIF orientation after decoding
+		imageData, err := ioutil.ReadAll(f)
 
 		if err != nil {
 			errorDialog := dialog.NewError(err, mainWindow)
 			errorDialog.Show()
+			return
+		}
 
-		} else {
+		// Unsupported, damaged and too large images are refused before decoding
+		if err := validateImage(imageData); err != nil {
+			dialog.ShowInformation(tr("Image cannot be added"), imageErrorMessage(err), mainWindow)
+			return
+		}
+
+		decodedImage, _, err := image.Decode(bytes.NewReader(imageData))
+
+		if err != nil {
+			dialog.ShowInformation(tr("Image cannot be added"), imageErrorMessage(err), mainWindow)
+			return
+		}
+
+		// The user can rotate and crop the upright image before it is resized
+		showImageEditor(prepareImage(decodedImage, imageData), func(chosenImage image.Image) {
 
 			bounds := chosenImage.Bounds()
 			width := bounds.Dx()
@@ -470,29 +1043,23 @@ func recipeEntry(recipe Recipe, mode string) {
 				}
 			}
 
-			// Encode as jpeg and save in recipeImage
+			// Encode as jpeg and add to recipe images
 			imageBuffer := new(bytes.Buffer)
-			jpeg.Encode(imageBuffer, chosenImage, nil)
-			recipeImage = imageBuffer.Bytes()
+			jpeg.Encode(imageBuffer, chosenImage, &jpeg.Options{Quality: config.jpegQuality})
+			recipeImageList = append(recipeImageList, imageBuffer.Bytes())
 
-			imageRes := fyne.NewStaticResource(f.URI().Name(), recipeImage)
-			canvasImage := canvas.NewImageFromResource(imageRes)
-			canvasImage.FillMode = canvas.ImageFillContain
-			canvasImage.SetMinSize(fyne.NewSize(100, 100))
-
-			// Modify container to include the new image
-			addImageContainer.RemoveAll()
-			addImageContainer.Add(container.NewHBox(container.NewVBox(layout.NewSpacer(), addImageButton, layout.NewSpacer()), layout.NewSpacer()))
-			addImageContainer.Add(canvasImage)
+			// Thumbnail in case the image becomes the cover
+			recipeThumbnails[imageHash(imageBuffer.Bytes())] = createThumbnail(chosenImage)
 
-			addImageButton.SetText("Change image")
+			refreshImageStrip()
 
-		}
+		})
 
 	}
 
 	addImageButton.OnTapped = func() {
 		fileDialog := dialog.NewFileOpen(addImage, mainWindow)
+		fileDialog.SetFilter(storage.NewExtensionFileFilter(imageExtensions))
 		fileDialog.Show()
 	}
 
@@ -507,41 +1074,45 @@ func recipeEntry(recipe Recipe, mode string) {
 		mainIngredientSelect.Text = recipe.MainIngredient
 		countrySelect.Text = recipe.Country
 
-		ingredientData = [][]*widget.Entry{}
-		ingrContainer = container.NewVBox()
+		previousGroup := ""
+		for _, ingr := range recipe.Ingredients {
 
-		for j, ingr := range recipe.Ingredients {
-
-			name, qty, unit, note = createIngredientRow(len(ingrContainer.Objects) + 1)
-			name.Text, qty.Text, unit.Text, note.Text = ingr.Name, fmt.Sprint(ingr.Quantity), ingr.Unit, ingr.Notes
-			ingredientData = append(ingredientData, []*widget.Entry{name, qty, unit, note})
-			extraIngredientEntry := container.NewHBox(name, qty, unit, note)
-
-			if j == 0 {
-				ingrContainer.Add(container.NewBorder(nil, nil, nil, addIngrButton, extraIngredientEntry))
+			if ingr.Group != previousGroup && len(ingr.Group) != 0 {
+				ingredientData = append(ingredientData, newGroupRow(ingr.Group))
+			}
+			previousGroup = ingr.Group
 
-			} else {
-				ingrContainer.Add(container.NewBorder(nil, nil, nil, nil, extraIngredientEntry))
+			if len(ingr.RecipeId) != 0 {
+				ingredientData = append(ingredientData, newReferenceRow(ingr))
+				continue
 			}
 
+			row := newIngredientRow()
+			row[0].Text, row[1].Text, row[2].Text, row[3].Text = ingr.Name, formatNumber(ingr.Quantity, -1), ingr.Unit, ingr.Notes
+			ingredientData = append(ingredientData, row)
 		}
 
-		ingrContainer.Refresh()
+	}
 
+	// New recipes start with one empty ingredient row
+	if len(ingredientData) == 0 {
+		ingredientData = append(ingredientData, newIngredientRow())
 	}
 
-	// Display current image for edit mode if image exists
+	refreshIngredientRows()
+
+	// Display current images for edit mode
 	if mode == "edit" {
-		if len(recipe.Image) != 0 {
-			addImageButton.SetText("Change image")
+		recipeImageList = recipeImages(recipe)
+		refreshImageStrip()
+	}
 
-			imageRes := fyne.NewStaticResource(recipe.Id, recipe.Image)
-			canvasImage := canvas.NewImageFromResource(imageRes)
-			canvasImage.FillMode = canvas.ImageFillContain
-			canvasImage.SetMinSize(fyne.NewSize(100, 100))
-			addImageContainer.Add(canvasImage)
+	// Leaving the form asks for confirmation once anything was changed
+	initialRecipe := enteredRecipe()
+	initialImages := slices.Clone(recipeImageList)
 
-		}
+	recipeEntryChanged = func() bool {
+		return !reflect.DeepEqual(enteredRecipe(), initialRecipe) || !slices.EqualFunc(recipeImageList, initialImages, bytes.Equal)
 	}
 
 	// Page layout
@@ -553,29 +1124,33 @@ func recipeEntry(recipe Recipe, mode string) {
 		categorySelect,
 		mainIngredientSelect,
 		countrySelect,
+		tagEditor,
 		ingrContainer,
+		ingrButtons,
 		addImageContainer,
 		container.NewHBox(layout.NewSpacer(), backButton, submitButton, layout.NewSpacer()),
 	)
 
+	// Long ingredient lists don't fit on the screen
 	if isMobile {
-		mainWindow.SetContent(recipeEntryContainer)
+		mainWindow.SetContent(container.NewVScroll(recipeEntryContainer))
 
 	} else {
-		mainWindow.SetContent(container.NewBorder(nil, nil, container.NewBorder(nil, newRecipeButton, nil, nil, navTree), nil, recipeEntryContainer))
+		allPages := int(math.Ceil(float64(currentCount) / float64(config.resultsPerPage)))
+		showInDetailPane(container.NewVScroll(recipeEntryContainer), allPages, currentResultsTitle)
 	}
 
 }
 
-func createIngredientRow(i int) (*widget.Entry, *widget.Entry, *widget.Entry, *widget.Entry) {
+func createIngredientRow() (*FormEntry, *FormEntry, *FormEntry, *FormEntry) {
 
-	w1 := &widget.Entry{PlaceHolder: "Ingredient " + fmt.Sprint(i)}
-	w2 := &widget.Entry{PlaceHolder: "Quantity"}
-	w3 := &widget.Entry{PlaceHolder: "Unit"}
-	w4 := &widget.Entry{PlaceHolder: "Note"}
+	w1 := newFormEntry(tr("Ingredient"))
+	w2 := newFormEntry(tr("Quantity"))
+	w3 := newFormEntry(tr("Unit"))
+	w4 := newFormEntry(tr("Note"))
 
 	// Decimal number validation
-	w2.Validator = validation.NewRegexp(`^\d*(\.)?(\d{0,3})?$`, "Value has to be a number.")
+	w2.Validator = validation.NewRegexp(`^\d*([.,])?(\d{0,3})?$`, tr("Value has to be a number."))
 
 	return w1, w2, w3, w4
 }
@@ -0,0 +1,470 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math"
+	"strconv"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+// Food table imported by the user, added on top of the bundled one
+const importedNutrientsFile = "nutrients.csv"
+
+// Manual links between ingredient names and foods
+const nutritionLinksFile = "nutritionLinks.json"
+
+// NutritionFacts holds energy and macronutrients, either per 100 g of food or for an amount of food
+type NutritionFacts struct {
+	Energy       float64
+	Protein      float64
+	Fat          float64
+	Carbohydrate float64
+	Fibre        float64
+}
+
+// Food is one entry of the food composition table
+type Food struct {
+	Name           string
+	Per100g        NutritionFacts
+	GramsPerPiece  float64
+	DensityGramsMl float64
+}
+
+type UnmappedIngredient struct {
+	Ingredient Ingredient
+	Reason     string
+}
+
+var foodTable map[string]Food
+
+// Ingredient name -> food name
+var nutritionLinks map[string]string
+
+// Grams per unit for weight units
+var weightUnits = map[string]float64{
+	"g": 1, "gram": 1, "grams": 1, "gr": 1,
+	"kg": 1000, "dag": 10, "mg": 0.001,
+	"oz": 28.35, "lb": 453.6,
+	"pinch": 0.4, "scepec": 0.4,
+}
+
+// Millilitres per unit for volume units
+var volumeUnits = map[string]float64{
+	"ml": 1, "cl": 10, "dl": 100, "l": 1000,
+	"tsp": 5, "teaspoon": 5, "zlicka": 5,
+	"tbsp": 15, "tablespoon": 15, "zlica": 15,
+	"cup": 240, "skodelica": 240,
+}
+
+// Units that count whole pieces of food
+var pieceUnits = map[string]bool{
+	"": true, "pc": true, "pcs": true, "piece": true, "pieces": true,
+	"kos": true, "clove": true, "cloves": true, "whole": true,
+}
+
+// loadNutrition loads the bundled food table, foods imported by the user and manual ingredient links
+func loadNutrition() {
+
+	foodTable = map[string]Food{}
+
+	bundledFoods, _ := parseFoodTable(resourceNutrientsCsv.Content())
+	for _, food := range bundledFoods {
+		foodTable[food.Name] = food
+	}
+
+	if importedTable, err := readLocalFile(importedNutrientsFile); err == nil {
+		importedFoods, _ := parseFoodTable(importedTable)
+		for _, food := range importedFoods {
+			foodTable[food.Name] = food
+		}
+	}
+
+	nutritionLinks = map[string]string{}
+	loadLocalJSON(nutritionLinksFile, &nutritionLinks)
+}
+
+// parseFoodTable reads a food composition table in CSV format with values per 100 g and columns named
+// name, energy_kcal, protein_g, fat_g, carbohydrate_g, fibre_g, grams_per_piece and density_g_ml.
+// Both comma separated files and semicolon separated files with decimal commas are accepted. Rows too short to have a name and energy are skipped.
+func parseFoodTable(content []byte) ([]Food, error) {
+
+	firstLine := content
+	if end := bytes.IndexByte(content, '\n'); end >= 0 {
+		firstLine = content[:end]
+	}
+
+	semicolons := bytes.Contains(firstLine, []byte(";"))
+
+	reader := csv.NewReader(bytes.NewReader(content))
+	reader.FieldsPerRecord = -1
+
+	if semicolons {
+		reader.Comma = ';'
+	}
+
+	header, err := reader.Read()
+
+	if err != nil {
+		return []Food{}, err
+	}
+
+	columns := map[string]int{}
+	for j, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = j
+	}
+
+	for _, required := range []string{"name", "energy_kcal"} {
+		if _, exists := columns[required]; !exists {
+			return []Food{}, fmt.Errorf(tr("Food table has no %s column."), required)
+		}
+	}
+
+	value := func(record []string, column string) float64 {
+
+		j, exists := columns[column]
+
+		if !exists || j >= len(record) {
+			return 0
+		}
+
+		text := strings.TrimSpace(record[j])
+		if semicolons {
+			text = strings.ReplaceAll(text, ",", ".")
+		}
+
+		number, _ := strconv.ParseFloat(text, 64)
+		return number
+	}
+
+	foods := []Food{}
+
+	for {
+		record, err := reader.Read()
+
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			return foods, err
+		}
+
+		// Short or blank rows have no value in the required columns
+		if len(record) <= columns["name"] || len(record) <= columns["energy_kcal"] {
+			continue
+		}
+
+		name := normalizeFoodName(record[columns["name"]])
+
+		if len(name) == 0 {
+			continue
+		}
+
+		foods = append(foods, Food{
+			Name: name,
+			Per100g: NutritionFacts{
+				Energy:       value(record, "energy_kcal"),
+				Protein:      value(record, "protein_g"),
+				Fat:          value(record, "fat_g"),
+				Carbohydrate: value(record, "carbohydrate_g"),
+				Fibre:        value(record, "fibre_g"),
+			},
+			GramsPerPiece:  value(record, "grams_per_piece"),
+			DensityGramsMl: value(record, "density_g_ml"),
+		})
+	}
+
+	return foods, nil
+}
+
+func normalizeFoodName(name string) string {
+	return strings.Join(strings.Fields(strings.ToLower(name)), " ")
+}
+
+// findFood returns the food for an ingredient, using manual links first and then matching by name.
+// Matching ignores plurals and synonyms and prefers the longest food name contained in the ingredient name,
+// so "chicken breast fillets" maps to "chicken breast" rather than "chicken".
+func findFood(ingredientName string) (Food, bool) {
+
+	name := normalizeFoodName(ingredientName)
+
+	if linkedName, exists := nutritionLinks[name]; exists {
+		food, exists := foodTable[linkedName]
+		return food, exists
+	}
+
+	var bestFood Food
+	bestLength := 0
+
+	for _, variant := range ingredientSynonyms(name) {
+
+		variantTerms := tokenize(variant)
+
+		for _, food := range foodTable {
+
+			foodTerms := tokenize(food.Name)
+
+			if len(foodTerms) == 0 || len(foodTerms) <= bestLength || !containsAllTerms(variantTerms, foodTerms) {
+				continue
+			}
+
+			bestFood = food
+			bestLength = len(foodTerms)
+		}
+	}
+
+	return bestFood, bestLength > 0
+}
+
+func containsAllTerms(terms []string, required []string) bool {
+
+	for _, requiredTerm := range required {
+
+		found := false
+		for _, term := range terms {
+			if term == requiredTerm {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
+
+// normalizeUnit makes units case and accent insensitive, so "Žlica" and "žlica" are the same unit
+func normalizeUnit(unit string) string {
+	return strings.TrimSuffix(strings.ToLower(foldAccents(strings.TrimSpace(unit))), ".")
+}
+
+// ingredientGrams converts an ingredient quantity to grams of the given food
+func ingredientGrams(ingr Ingredient, food Food) (float64, error) {
+
+	unit := normalizeUnit(ingr.Unit)
+
+	if grams, exists := weightUnits[unit]; exists {
+		return ingr.Quantity * grams, nil
+	}
+
+	if millilitres, exists := volumeUnits[unit]; exists {
+
+		// Without a known density, liquids are assumed to weigh about as much as water
+		density := food.DensityGramsMl
+		if density == 0 {
+			density = 1
+		}
+
+		return ingr.Quantity * millilitres * density, nil
+	}
+
+	if pieceUnits[unit] {
+
+		if food.GramsPerPiece == 0 {
+			return 0, errors.New(tr("weight of one piece is unknown"))
+		}
+
+		return ingr.Quantity * food.GramsPerPiece, nil
+	}
+
+	return 0, fmt.Errorf(tr("unit %s cannot be converted to grams"), ingr.Unit)
+}
+
+// calculateNutrition returns nutrition facts of the whole recipe and the ingredients that could not be included
+func calculateNutrition(ingredientList []Ingredient) (total NutritionFacts, unmapped []UnmappedIngredient) {
+
+	unmapped = []UnmappedIngredient{}
+
+	for _, ingr := range ingredientList {
+
+		// Ingredients like "salt to taste" have no quantity and are not counted
+		if ingr.Quantity == 0 {
+			continue
+		}
+
+		food, found := findFood(ingr.Name)
+
+		if !found {
+			unmapped = append(unmapped, UnmappedIngredient{Ingredient: ingr, Reason: tr("no matching food")})
+			continue
+		}
+
+		grams, err := ingredientGrams(ingr, food)
+
+		if err != nil {
+			unmapped = append(unmapped, UnmappedIngredient{Ingredient: ingr, Reason: err.Error()})
+			continue
+		}
+
+		total = total.add(food.Per100g.scale(grams / 100))
+	}
+
+	return total, unmapped
+}
+
+func (facts NutritionFacts) scale(factor float64) NutritionFacts {
+	return NutritionFacts{
+		Energy:       facts.Energy * factor,
+		Protein:      facts.Protein * factor,
+		Fat:          facts.Fat * factor,
+		Carbohydrate: facts.Carbohydrate * factor,
+		Fibre:        facts.Fibre * factor,
+	}
+}
+
+func (facts NutritionFacts) add(other NutritionFacts) NutritionFacts {
+	return NutritionFacts{
+		Energy:       facts.Energy + other.Energy,
+		Protein:      facts.Protein + other.Protein,
+		Fat:          facts.Fat + other.Fat,
+		Carbohydrate: facts.Carbohydrate + other.Carbohydrate,
+		Fibre:        facts.Fibre + other.Fibre,
+	}
+}
+
+func (facts NutritionFacts) String() string {
+	return fmt.Sprintf(tr("%d kcal | protein %s g | fat %s g | carbohydrates %s g | fibre %s g"),
+		int(math.Round(facts.Energy)),
+		formatNumber(math.Round(facts.Protein*10)/10, -1),
+		formatNumber(math.Round(facts.Fat*10)/10, -1),
+		formatNumber(math.Round(facts.Carbohydrate*10)/10, -1),
+		formatNumber(math.Round(facts.Fibre*10)/10, -1))
+}
+
+// createNutritionPanel shows nutrition facts of a recipe with a list of ingredients that need a manual link.
+// Dialogs are shown over parent. onChanged is called when links or the food table change.
+func createNutritionPanel(recipe Recipe, parent fyne.Window, onChanged func()) fyne.CanvasObject {
+
+	if foodTable == nil {
+		loadNutrition()
+	}
+
+	total, unmapped := calculateNutrition(recipe.Ingredients)
+
+	nutritionTitle := newHeading(tr("Nutrition:"), 16)
+
+	totalLabel := widget.NewLabel(tr("Total: ") + total.String())
+	totalLabel.Wrapping = fyne.TextWrapWord
+
+	panel := container.NewVBox(nutritionTitle, totalLabel)
+
+	if recipe.DefaultPortions > 0 {
+		portionLabel := widget.NewLabel(tr("Per portion: ") + total.scale(1/float64(recipe.DefaultPortions)).String())
+		portionLabel.Wrapping = fyne.TextWrapWord
+		panel.Add(portionLabel)
+	}
+
+	// Ingredients missing from the calculation
+	for _, missing := range unmapped {
+
+		ingr := missing.Ingredient
+
+		missingLabel := widget.NewLabel(tr("Not included: ") + ingr.Name + " (" + missing.Reason + ")")
+		missingLabel.Wrapping = fyne.TextWrapWord
+
+		linkButton := widget.NewButtonWithIcon(tr("Link"), theme.SearchIcon(), func() { showFoodLinkDialog(ingr.Name, parent, onChanged) })
+
+		panel.Add(container.NewBorder(nil, nil, nil, linkButton, missingLabel))
+	}
+
+	importButton := widget.NewButtonWithIcon(tr("Import food table"), theme.FolderOpenIcon(), func() { showFoodTableImport(parent, onChanged) })
+	panel.Add(container.NewHBox(importButton))
+
+	return panel
+}
+
+// showFoodLinkDialog lets the user choose the food that an ingredient name maps to
+func showFoodLinkDialog(ingredientName string, parent fyne.Window, onLinked func()) {
+
+	foodEntry := newSuggestionEntry(func(text string) []Suggestion {
+
+		candidates := []Suggestion{}
+		for name := range foodTable {
+			candidates = append(candidates, Suggestion{Text: name, Kind: "Food"})
+		}
+
+		return matchSuggestions(text, candidates)
+	})
+	foodEntry.SetPlaceHolder(tr("Food"))
+
+	formItems := []*widget.FormItem{widget.NewFormItem(ingredientName, foodEntry)}
+
+	dialog.ShowForm(tr("Link ingredient"), tr("Link"), tr("Cancel"), formItems, func(confirmed bool) {
+
+		foodName := normalizeFoodName(foodEntry.Text)
+
+		if !confirmed {
+			return
+		}
+
+		if _, exists := foodTable[foodName]; !exists {
+			dialog.NewInformation(tr("Error"), fmt.Sprintf(tr("Food %s is not in the food table."), foodEntry.Text), parent).Show()
+			return
+		}
+
+		nutritionLinks[normalizeFoodName(ingredientName)] = foodName
+
+		if err := saveLocalJSON(nutritionLinksFile, nutritionLinks); err != nil {
+			dialog.NewError(err, parent).Show()
+		}
+
+		onLinked()
+
+	}, parent)
+}
+
+// showFoodTableImport imports a food composition table from a CSV file chosen by the user
+func showFoodTableImport(parent fyne.Window, onImported func()) {
+
+	fileDialog := dialog.NewFileOpen(func(f fyne.URIReadCloser, err error) {
+
+		// In case file dialog is cancelled or file cannot be accessed
+		if err != nil || f == nil {
+			return
+		}
+
+		defer f.Close()
+
+		content, err := ioutil.ReadAll(f)
+
+		if err != nil {
+			dialog.NewError(err, parent).Show()
+			return
+		}
+
+		foods, err := parseFoodTable(content)
+
+		if err != nil {
+			dialog.NewError(err, parent).Show()
+			return
+		}
+
+		if err := writeLocalFile(importedNutrientsFile, content); err != nil {
+			dialog.NewError(err, parent).Show()
+			return
+		}
+
+		loadNutrition()
+		dialog.NewInformation(tr("OK"), fmt.Sprintf(tr("%d foods imported."), len(foods)), parent).Show()
+		onImported()
+
+	}, parent)
+
+	fileDialog.SetFilter(storage.NewExtensionFileFilter([]string{".csv"}))
+	fileDialog.Show()
+}
@@ -0,0 +1,70 @@
+package main
+
+import "testing"
+
+func TestParseFoodTable(t *testing.T) {
+
+	tests := []struct {
+		name    string
+		content string
+		foods   []Food
+		wantErr bool
+	}{
+		{
+			name:    "comma separated",
+			content: "name,energy_kcal,protein_g\nEgg,143,12.6\n",
+			foods:   []Food{{Name: "egg", Per100g: NutritionFacts{Energy: 143, Protein: 12.6}}},
+		},
+		{
+			name:    "semicolon separated with decimal commas",
+			content: "Name;Energy_kcal;fat_g\nOlive  Oil;884;100\nButter;717;81,1\n",
+			foods: []Food{
+				{Name: "olive oil", Per100g: NutritionFacts{Energy: 884, Fat: 100}},
+				{Name: "butter", Per100g: NutritionFacts{Energy: 717, Fat: 81.1}},
+			},
+		},
+		{
+			name:    "short and blank rows are skipped",
+			content: "energy_kcal,protein_g,name\n143\n\n,,\n52,0.3,Apple\n",
+			foods:   []Food{{Name: "apple", Per100g: NutritionFacts{Energy: 52, Protein: 0.3}}},
+		},
+		{
+			name:    "missing required column",
+			content: "name,protein_g\nEgg,12.6\n",
+			wantErr: true,
+		},
+		{
+			name:    "empty file",
+			content: "",
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			foods, err := parseFoodTable([]byte(test.content))
+
+			if test.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error, got %v", foods)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if len(foods) != len(test.foods) {
+				t.Fatalf("got %d foods %v, want %d", len(foods), foods, len(test.foods))
+			}
+
+			for j := range foods {
+				if foods[j] != test.foods[j] {
+					t.Errorf("food %d = %+v, want %+v", j, foods[j], test.foods[j])
+				}
+			}
+		})
+	}
+}
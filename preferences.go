@@ -0,0 +1,140 @@
+package main
+
+import (
+	"strconv"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/data/validation"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+)
+
+// loadPreferences replaces config values with the ones the user changed in preferences
+func loadPreferences() {
+
+	preferences := mainApp.Preferences()
+
+	config.maximumImageSizePx = uint(preferences.IntWithFallback("maximumImageSizePx", int(config.maximumImageSizePx)))
+	config.resultsPerPage = preferences.IntWithFallback("resultsPerPage", config.resultsPerPage)
+	config.jpegQuality = preferences.IntWithFallback("jpegQuality", config.jpegQuality)
+	config.desktopDefaultWidth = float32(preferences.FloatWithFallback("windowWidth", float64(config.desktopDefaultWidth)))
+	config.desktopDefaultHeight = float32(preferences.FloatWithFallback("windowHeight", float64(config.desktopDefaultHeight)))
+	config.theme = preferences.StringWithFallback("theme", config.theme)
+	config.unitSystem = preferences.StringWithFallback("unitSystem", config.unitSystem)
+	config.language = preferences.StringWithFallback("language", config.language)
+	config.resultsView = preferences.StringWithFallback("resultsView", config.resultsView)
+	config.resultsPaging = preferences.StringWithFallback("resultsPaging", config.resultsPaging)
+}
+
+// keyForName returns the key of a translated display name in a map of names
+func keyForName(names map[string]string, name string) string {
+
+	for key, value := range names {
+		if tr(value) == name {
+			return key
+		}
+	}
+
+	return ""
+}
+
+// showPreferencesDialog lets the user change app preferences, which are stored and applied at once
+func showPreferencesDialog() {
+
+	numberValidator := validation.NewRegexp(`^[1-9][0-9]*$`, tr("Value has to be a positive whole number."))
+
+	imageSizeEntry := &widget.Entry{Text: strconv.Itoa(int(config.maximumImageSizePx)), Validator: numberValidator}
+	qualityEntry := &widget.Entry{Text: strconv.Itoa(config.jpegQuality), Validator: validation.NewRegexp(`^([1-9][0-9]?|100)$`, tr("Value has to be a whole number from 1 to 100."))}
+	resultsEntry := &widget.Entry{Text: strconv.Itoa(config.resultsPerPage), Validator: numberValidator}
+	widthEntry := &widget.Entry{Text: strconv.Itoa(int(config.desktopDefaultWidth)), Validator: numberValidator}
+	heightEntry := &widget.Entry{Text: strconv.Itoa(int(config.desktopDefaultHeight)), Validator: numberValidator}
+
+	themeSelect := widget.NewSelect([]string{tr(themeNames[themeSystem]), tr(themeNames[themeLight]), tr(themeNames[themeDark])}, func(string) {})
+	themeSelect.SetSelected(tr(themeNames[config.theme]))
+
+	unitSelect := widget.NewSelect([]string{tr(unitSystemNames[unitSystemOriginal]), tr(unitSystemNames[unitSystemMetric]), tr(unitSystemNames[unitSystemUS])}, func(string) {})
+	unitSelect.SetSelected(tr(unitSystemNames[config.unitSystem]))
+
+	pagingSelect := widget.NewSelect([]string{tr(resultsPagingNames[resultsPagingPages]), tr(resultsPagingNames[resultsPagingScroll])}, func(string) {})
+	pagingSelect.SetSelected(tr(resultsPagingNames[config.resultsPaging]))
+
+	// Languages are listed by their own names
+	languageSelect := widget.NewSelect([]string{languageNames[languageEnglish], languageNames[languageSlovenian]}, func(string) {})
+	languageSelect.SetSelected(languageNames[config.language])
+
+	formItems := []*widget.FormItem{
+		widget.NewFormItem(tr("Image size (px)"), imageSizeEntry),
+		widget.NewFormItem(tr("JPEG quality"), qualityEntry),
+		widget.NewFormItem(tr("Results per page"), resultsEntry),
+		widget.NewFormItem(tr("Paging"), pagingSelect),
+	}
+
+	// Mobile apps always fill the screen
+	if !isMobile {
+		formItems = append(formItems,
+			widget.NewFormItem(tr("Window width"), widthEntry),
+			widget.NewFormItem(tr("Window height"), heightEntry),
+		)
+	}
+
+	formItems = append(formItems,
+		widget.NewFormItem(tr("Theme"), themeSelect),
+		widget.NewFormItem(tr("Units"), unitSelect),
+		widget.NewFormItem(tr("Language"), languageSelect),
+	)
+
+	dialog.ShowForm(tr("Preferences"), tr("Save"), tr("Cancel"), formItems, func(confirmed bool) {
+
+		if !confirmed {
+			return
+		}
+
+		imageSize, _ := strconv.Atoi(imageSizeEntry.Text)
+		quality, _ := strconv.Atoi(qualityEntry.Text)
+		resultsPerPage, _ := strconv.Atoi(resultsEntry.Text)
+		width, _ := strconv.Atoi(widthEntry.Text)
+		height, _ := strconv.Atoi(heightEntry.Text)
+
+		paging := keyForName(resultsPagingNames, pagingSelect.Selected)
+		resultsChanged := resultsPerPage != config.resultsPerPage || paging != config.resultsPaging
+		sizeChanged := float32(width) != config.desktopDefaultWidth || float32(height) != config.desktopDefaultHeight
+
+		language := keyForName(languageNames, languageSelect.Selected)
+		languageChanged := language != config.language
+
+		preferences := mainApp.Preferences()
+		preferences.SetInt("maximumImageSizePx", imageSize)
+		preferences.SetInt("jpegQuality", quality)
+		preferences.SetInt("resultsPerPage", resultsPerPage)
+		preferences.SetString("resultsPaging", paging)
+		preferences.SetFloat("windowWidth", float64(width))
+		preferences.SetFloat("windowHeight", float64(height))
+		preferences.SetString("theme", keyForName(themeNames, themeSelect.Selected))
+		preferences.SetString("unitSystem", keyForName(unitSystemNames, unitSelect.Selected))
+		preferences.SetString("language", language)
+
+		loadPreferences()
+		applyTheme()
+
+		if sizeChanged && !isMobile {
+			mainWindow.Resize(fyne.NewSize(config.desktopDefaultWidth, config.desktopDefaultHeight))
+		}
+
+		// The search panel and navigation are created again with texts in the new language
+		if languageChanged {
+			createSearchPanel()
+			navTree.Refresh()
+		}
+
+		// Pages are counted again with the new page size or paging
+		if resultsChanged || languageChanged {
+			refreshCurrentResults()
+		}
+
+		// Mobile home page has no results to refresh
+		if languageChanged && len(currentQuery["type"]) == 0 {
+			displayHomePage()
+		}
+
+	}, mainWindow)
+}
@@ -3,14 +3,23 @@ package main
 import (
 	"bytes"
 	"encoding/json"
+	"fmt"
 	"io/ioutil"
 	"net/http"
+	"regexp"
+	"sort"
+	"strings"
 
 	"fyne.io/fyne/v2/dialog"
 )
 
 type pipelineStage map[string]interface{}
 
+// Result lists only need thumbnails, so full images are left out
+func withoutImageStage() pipelineStage {
+	return pipelineStage{"$project": map[string]int{"image": 0}}
+}
+
 type getRecipesResponse struct {
 	Documents []struct {
 		Recipes    []Recipe
@@ -88,25 +97,99 @@ func getDistinctFieldValues(fieldName string) []string {
 	return fieldValues
 }
 
+// getDistinctArrayValues returns all distinct values of a field inside an array of subdocuments, e.g. ingredient names
+func getDistinctArrayValues(arrayName string, fieldName string) []string {
+
+	// Split arrays into separate documents, then group by chosen field
+	unwindStage := pipelineStage{"$unwind": "$" + arrayName}
+	groupStage := pipelineStage{"$group": map[string]string{"_id": "$" + arrayName + "." + fieldName}}
+
+	var response struct {
+		Documents []map[string]string
+	}
+
+	if err := aggregate([]pipelineStage{unwindStage, groupStage}, &response); err != nil {
+		dialog.NewError(err, mainWindow).Show()
+		return []string{}
+	}
+
+	// Names are entered by hand, so the same ingredient can be written with different case or spacing
+	fieldValues := []string{}
+	seen := map[string]bool{}
+
+	for _, doc := range response.Documents {
+
+		value := strings.TrimSpace(doc["_id"])
+		key := strings.ToLower(value)
+
+		if len(value) == 0 || seen[key] {
+			continue
+		}
+
+		seen[key] = true
+		fieldValues = append(fieldValues, value)
+	}
+
+	sort.Slice(fieldValues, func(i, j int) bool { return strings.ToLower(fieldValues[i]) < strings.ToLower(fieldValues[j]) })
+
+	return fieldValues
+}
+
 func getRecipes(fieldName string, fieldValue string, offset int, perPage int) (results []Recipe, totalCount int) {
 
-	httpClient := http.Client{}
+	filter := map[string]interface{}{} // Match all documents
 
-	var matchStage pipelineStage
+	if fieldName != "" {
+		filter[fieldName] = fieldValue
+	}
 
-	if fieldName == "" {
-		matchStage = pipelineStage{"$match": map[string]string{}} // Match all documents
+	return getRecipesByFilter(filter, offset, perPage)
+}
 
-	} else {
-		matchStage = pipelineStage{"$match": map[string]string{fieldName: fieldValue}}
+// getRecipesByIngredient returns recipes that contain an ingredient or one of its synonyms anywhere in the ingredient list
+func getRecipesByIngredient(ingredientName string, offset int, perPage int) (results []Recipe, totalCount int) {
+
+	filter := map[string]interface{}{
+		"ingredients.name": map[string]string{
+			"$regex":   ingredientNamePattern(ingredientName),
+			"$options": "i",
+		},
 	}
 
+	return getRecipesByFilter(filter, offset, perPage)
+}
+
+// ingredientNamePattern returns a regular expression matching an ingredient name or its synonyms as whole words,
+// optionally in plural, so that "egg" matches "2 eggs" but not "eggplant"
+func ingredientNamePattern(ingredientName string) string {
+
+	namePatterns := []string{}
+	for _, name := range ingredientSynonyms(ingredientName) {
+		namePatterns = append(namePatterns, regexp.QuoteMeta(strings.TrimSpace(name)))
+	}
+
+	return wholeWordsPattern(namePatterns)
+}
+
+// wholeWordsPattern returns a regular expression matching any of the word patterns as whole words, optionally in plural.
+// Letters and digits around the words are not allowed, so it also works for words with letters outside of ASCII.
+func wholeWordsPattern(wordPatterns []string) string {
+	return `(^|[^\p{L}\p{N}])(` + strings.Join(wordPatterns, "|") + `)(s|es)?($|[^\p{L}\p{N}])`
+}
+
+// getRecipesByFilter returns one page of recipes matching a MongoDB query filter and the count of all matched recipes
+func getRecipesByFilter(filter map[string]interface{}, offset int, perPage int) (results []Recipe, totalCount int) {
+
+	httpClient := http.Client{}
+
+	matchStage := pipelineStage{"$match": withAllergenExclusions(filter)}
+
 	skipStage := pipelineStage{"$skip": offset}
 	limitStage := pipelineStage{"$limit": perPage}
 	countStage := pipelineStage{"$count": "totalCount"}
 
 	// Two pipelines - one for a limited number of documents, the other for the count of all matched documents
-	resultPipeline := []pipelineStage{matchStage, skipStage, limitStage}
+	resultPipeline := []pipelineStage{matchStage, skipStage, limitStage, withoutImageStage()}
 	countPipeline := []pipelineStage{matchStage, countStage}
 
 	combinedPipeline := []map[string]map[string][]pipelineStage{{
@@ -171,9 +254,23 @@ func getRecipes(fieldName string, fieldValue string, offset int, perPage int) (r
 	}
 }
 
-// getRecipesByText uses Atlas Search to perform full text search on documents
+// getRecipesByText performs full text search on documents. Atlas Search is used when available,
+// otherwise the search falls back to the local search index.
 func getRecipesByText(searchTerm string, offset int, perPage int) (results []Recipe, totalCount int) {
 
+	results, totalCount, err := getRecipesByAtlasSearch(searchTerm, offset, perPage)
+
+	// Atlas returns no results without an error if the search index does not exist
+	if err != nil || totalCount == 0 {
+		return localIndex.searchPage(searchTerm, offset, perPage)
+	}
+
+	return results, totalCount
+}
+
+// getRecipesByAtlasSearch uses Atlas Search to perform full text search on documents
+func getRecipesByAtlasSearch(searchTerm string, offset int, perPage int) (results []Recipe, totalCount int, err error) {
+
 	httpClient := http.Client{}
 
 	searchStage := pipelineStage{"$search": map[string]interface{}{
@@ -193,7 +290,20 @@ func getRecipesByText(searchTerm string, offset int, perPage int) (results []Rec
 		"meta": []interface{}{map[string]string{"$replaceWith": "$$SEARCH_META"}, map[string]int{"$limit": 1}},
 	}}
 
-	pipeline := []pipelineStage{searchStage, skipStage, limitStage, countStage}
+	pipeline := []pipelineStage{searchStage, skipStage, limitStage, withoutImageStage(), countStage}
+
+	// Excluded allergens are filtered after the search, so matched documents are counted instead of using SEARCH_META
+	if len(allergenExclusionFilters()) != 0 {
+
+		matchStage := pipelineStage{"$match": withAllergenExclusions(map[string]interface{}{})}
+
+		countStage = pipelineStage{"$facet": map[string]interface{}{
+			"docs": []pipelineStage{skipStage, limitStage, withoutImageStage()},
+			"meta": []pipelineStage{{"$count": "total"}, {"$project": map[string]interface{}{"count": map[string]string{"total": "$total"}}}},
+		}}
+
+		pipeline = []pipelineStage{searchStage, matchStage, countStage}
+	}
 
 	body := map[string]interface{}{
 		"dataSource": "mongodb-atlas",
@@ -207,9 +317,7 @@ func getRecipesByText(searchTerm string, offset int, perPage int) (results []Rec
 	req, err := http.NewRequest("POST", "https://eu-central-1.aws.data.mongodb-api.com/app/"+credentials["appId"]+"/endpoint/data/v1/action/aggregate", bytes.NewBuffer(jsonBody))
 
 	if err != nil {
-		errorDialog := dialog.NewError(err, mainWindow)
-		errorDialog.Show()
-		return []Recipe{}, 0
+		return []Recipe{}, 0, err
 	}
 
 	req.Header.Add("Accept", "application/json")
@@ -219,37 +327,164 @@ func getRecipesByText(searchTerm string, offset int, perPage int) (results []Rec
 	rawResponse, err := httpClient.Do(req)
 
 	if err != nil {
-		errorDialog := dialog.NewError(err, mainWindow)
-		errorDialog.Show()
-		return []Recipe{}, 0
+		return []Recipe{}, 0, err
 	}
 
 	defer rawResponse.Body.Close()
 
+	if rawResponse.StatusCode != 200 {
+		return []Recipe{}, 0, fmt.Errorf("search failed: %d", rawResponse.StatusCode)
+	}
+
 	responseBody, err := ioutil.ReadAll(rawResponse.Body)
 
 	if err != nil {
-		errorDialog := dialog.NewError(err, mainWindow)
-		errorDialog.Show()
-		return []Recipe{}, 0
+		return []Recipe{}, 0, err
 	}
+
 	var response getRecipesByTextResponse
 
 	if err := json.Unmarshal(responseBody, &response); err != nil {
+		return []Recipe{}, 0, err
+	}
+
+	if len(response.Documents) == 0 || len(response.Documents[0].Meta) == 0 {
+		return []Recipe{}, 0, nil
+	}
+
+	docs := response.Documents[0]
+
+	return docs.Docs, docs.Meta[0]["count"]["total"], nil
+
+}
+
+// getAllRecipes returns all recipes in the collection without images, used for building the local search index
+func getAllRecipes() (results []Recipe, err error) {
+
+	var response struct {
+		Documents []Recipe
+	}
+
+	if err := aggregate([]pipelineStage{withoutImageStage()}, &response); err != nil {
+		return []Recipe{}, err
+	}
+
+	return response.Documents, nil
+}
+
+// getTagCounts returns all tags used on recipes with the number of recipes for each tag, most used first
+func getTagCounts() []TagCount {
+
+	unwindStage := pipelineStage{"$unwind": "$tags"}
+	groupStage := pipelineStage{"$group": map[string]interface{}{"_id": "$tags", "count": map[string]int{"$sum": 1}}}
+	sortStage := pipelineStage{"$sort": map[string]int{"count": -1, "_id": 1}}
+
+	var response struct {
+		Documents []TagCount
+	}
+
+	err := aggregate([]pipelineStage{unwindStage, groupStage, sortStage}, &response)
+
+	if err != nil {
 		errorDialog := dialog.NewError(err, mainWindow)
 		errorDialog.Show()
-		return []Recipe{}, 0
+		return []TagCount{}
 	}
 
-	docs := response.Documents[0]
+	return response.Documents
+}
 
-	if len(docs.Docs) == 0 {
-		return docs.Docs, 0
+// getRecipesByTags returns recipes that have all of the given tags, or any of them if matchAll is false
+func getRecipesByTags(tags []string, matchAll bool, offset int, perPage int) (results []Recipe, totalCount int) {
 
-	} else {
-		return docs.Docs, docs.Meta[0]["count"]["total"]
+	operator := "$in"
+	if matchAll {
+		operator = "$all"
+	}
+
+	filter := map[string]interface{}{"tags": map[string][]string{operator: tags}}
+
+	return getRecipesByFilter(filter, offset, perPage)
+}
+
+// getRecipesByIds returns recipes with the given IDs, in no particular order
+func getRecipesByIds(ids []string) []Recipe {
+
+	objectIds := []map[string]string{}
+	for _, id := range ids {
+		objectIds = append(objectIds, map[string]string{"$oid": id})
+	}
+
+	matchStage := pipelineStage{"$match": map[string]interface{}{"_id": map[string]interface{}{"$in": objectIds}}}
+
+	var response struct {
+		Documents []Recipe
+	}
+
+	if err := aggregate([]pipelineStage{matchStage, withoutImageStage()}, &response); err != nil {
+		errorDialog := dialog.NewError(err, mainWindow)
+		errorDialog.Show()
+		return []Recipe{}
+	}
+
+	return response.Documents
+}
+
+// aggregate runs an aggregation pipeline on the recipe collection and decodes the response into result
+func aggregate(pipeline []pipelineStage, result interface{}) error {
+
+	body := map[string]interface{}{
+		"collection": credentials["collection"],
+		"pipeline":   pipeline,
+	}
+
+	return callDataAPI("aggregate", body, result)
+}
+
+// callDataAPI sends a request to an Atlas Data API action and decodes the response into result.
+// The body has to contain the collection name, data source and database are added here.
+func callDataAPI(action string, body map[string]interface{}, result interface{}) error {
+
+	httpClient := http.Client{}
+
+	body["dataSource"] = "mongodb-atlas"
+	body["database"] = credentials["database"]
+
+	jsonBody, err := json.Marshal(body)
+
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest("POST", "https://eu-central-1.aws.data.mongodb-api.com/app/"+credentials["appId"]+"/endpoint/data/v1/action/"+action, bytes.NewBuffer(jsonBody))
+
+	if err != nil {
+		return err
+	}
+
+	req.Header.Add("Accept", "application/json")
+	req.Header.Add("content-type", "application/json")
+	req.Header.Add("email", credentials["email"])
+	req.Header.Add("password", credentials["password"])
+
+	rawResponse, err := httpClient.Do(req)
+
+	if err != nil {
+		return err
+	}
+
+	defer rawResponse.Body.Close()
+
+	if rawResponse.StatusCode != 200 && rawResponse.StatusCode != 201 {
+		responseBody, _ := ioutil.ReadAll(rawResponse.Body)
+		return fmt.Errorf("%s failed: %d %s", action, rawResponse.StatusCode, string(responseBody))
+	}
+
+	if result == nil {
+		return nil
 	}
 
+	return json.NewDecoder(rawResponse.Body).Decode(result)
 }
 
 // validateMongoLogin checks input credentials by making a query for one document
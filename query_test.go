@@ -0,0 +1,35 @@
+package main
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestIngredientNamePattern(t *testing.T) {
+
+	tests := []struct {
+		search     string
+		ingredient string
+		matches    bool
+	}{
+		{"egg", "egg", true},
+		{"egg", "2 Eggs, beaten", true},
+		{"egg", "eggplant", false},
+		{"salt", "sea salt", true},
+		{"salt", "unsalted butter", false},
+		{"oil", "olive oil", true},
+		{"oil", "boiled potatoes", false},
+		{"tomato", "tomatoes", true},
+		{"sir", "kozji sir", true},
+		{"sir", "siršen", false},
+	}
+
+	for _, test := range tests {
+
+		pattern := regexp.MustCompile("(?i)" + ingredientNamePattern(test.search))
+
+		if matched := pattern.MatchString(test.ingredient); matched != test.matches {
+			t.Errorf("%q matching %q = %v, want %v", test.search, test.ingredient, matched, test.matches)
+		}
+	}
+}
@@ -0,0 +1,311 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/data/validation"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+// Dates in the cook log are stored as text, so they can be compared in queries
+const cookLogDateFormat = "2006-01-02"
+
+type Rating struct {
+	User  string `json:"user"`
+	Stars int    `json:"stars"`
+}
+
+type CookLogEntry struct {
+	User     string `json:"user"`
+	Date     string `json:"date"`
+	Portions int    `json:"portions"`
+	Notes    string `json:"notes"`
+}
+
+// Ratings, favourites and the cook log belong to the app user that is logged in
+func currentUser() string {
+	return credentials["email"]
+}
+
+// averageRating returns the average of all users' ratings and the number of ratings
+func (recipe Recipe) averageRating() (average float64, count int) {
+
+	if len(recipe.Ratings) == 0 {
+		return 0, 0
+	}
+
+	sum := 0
+	for _, rating := range recipe.Ratings {
+		sum += rating.Stars
+	}
+
+	return float64(sum) / float64(len(recipe.Ratings)), len(recipe.Ratings)
+}
+
+// userRating returns the current user's rating or 0 if the recipe was not rated yet
+func (recipe Recipe) userRating() int {
+
+	for _, rating := range recipe.Ratings {
+		if rating.User == currentUser() {
+			return rating.Stars
+		}
+	}
+
+	return 0
+}
+
+func (recipe Recipe) isFavourite() bool {
+
+	for _, user := range recipe.FavouriteOf {
+		if user == currentUser() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// userCookLog returns the current user's cook log entries, most recent first
+func (recipe Recipe) userCookLog() []CookLogEntry {
+
+	entries := []CookLogEntry{}
+
+	for j := len(recipe.CookLog) - 1; j >= 0; j-- {
+		if recipe.CookLog[j].User == currentUser() {
+			entries = append(entries, recipe.CookLog[j])
+		}
+	}
+
+	return entries
+}
+
+// lastCooked returns the date the current user last made the recipe
+func (recipe Recipe) lastCooked() (date string, cooked bool) {
+
+	for _, entry := range recipe.CookLog {
+		if entry.User == currentUser() && entry.Date > date {
+			date = entry.Date
+			cooked = true
+		}
+	}
+
+	return date, cooked
+}
+
+// ratingSummary returns a short text with the average rating and the date the recipe was last cooked
+func ratingSummary(recipe Recipe) string {
+
+	parts := []string{}
+
+	if recipe.isFavourite() {
+		parts = append(parts, tr("Favourite"))
+	}
+
+	if average, count := recipe.averageRating(); count != 0 {
+		parts = append(parts, fmt.Sprintf(tr("Rating: %s/5 (%d)"), formatNumber(math.Round(average*10)/10, -1), count))
+	}
+
+	if date, cooked := recipe.lastCooked(); cooked {
+		parts = append(parts, tr("Last cooked: ")+date)
+	}
+
+	return strings.Join(parts, " | ")
+}
+
+// notCookedRecentlyFilter matches recipes the current user has not cooked in the configured number of days
+func notCookedRecentlyFilter() map[string]interface{} {
+
+	cutoff := time.Now().AddDate(0, 0, -config.notCookedRecentlyDays).Format(cookLogDateFormat)
+
+	return map[string]interface{}{
+		"cooklog": map[string]interface{}{
+			"$not": map[string]interface{}{
+				"$elemMatch": map[string]interface{}{
+					"user": currentUser(),
+					"date": map[string]string{"$gte": cutoff},
+				},
+			},
+		},
+	}
+}
+
+// setRating replaces the current user's rating of a recipe. Only the user's own rating is pulled and pushed,
+// so ratings other users saved since the recipe was loaded are kept.
+func (recipe *Recipe) setRating(stars int) bool {
+
+	// The same array cannot be pulled from and pushed to in one update
+	if !recipe.updateFields(map[string]interface{}{"$pull": map[string]map[string]string{"ratings": {"user": currentUser()}}}) {
+		return false
+	}
+
+	rating := Rating{User: currentUser(), Stars: stars}
+
+	if !recipe.updateFields(map[string]interface{}{"$push": map[string]Rating{"ratings": rating}}) {
+		return false
+	}
+
+	ratings := []Rating{}
+	for _, other := range recipe.Ratings {
+		if other.User != currentUser() {
+			ratings = append(ratings, other)
+		}
+	}
+	ratings = append(ratings, rating)
+
+	recipe.Ratings = ratings
+	return true
+}
+
+func (recipe *Recipe) setFavourite(favourite bool) bool {
+
+	operator := "$pull"
+	if favourite {
+		operator = "$addToSet"
+	}
+
+	if !recipe.updateFields(map[string]interface{}{operator: map[string]string{"favouriteof": currentUser()}}) {
+		return false
+	}
+
+	users := []string{}
+	for _, user := range recipe.FavouriteOf {
+		if user != currentUser() {
+			users = append(users, user)
+		}
+	}
+
+	if favourite {
+		users = append(users, currentUser())
+	}
+
+	recipe.FavouriteOf = users
+	return true
+}
+
+func (recipe *Recipe) addCookLogEntry(entry CookLogEntry) bool {
+
+	if !recipe.updateFields(map[string]interface{}{"$push": map[string]CookLogEntry{"cooklog": entry}}) {
+		return false
+	}
+
+	recipe.CookLog = append(recipe.CookLog, entry)
+	return true
+}
+
+// createRatingPanel creates controls for the user's rating, favourite flag and cook log of a displayed recipe.
+// The cook log dialog is shown over parent. onChanged is called with the updated recipe.
+func createRatingPanel(recipe Recipe, parent fyne.Window, onChanged func(Recipe)) fyne.CanvasObject {
+
+	ratingRadio := widget.NewRadioGroup([]string{"1", "2", "3", "4", "5"}, nil)
+	ratingRadio.Horizontal = true
+
+	if stars := recipe.userRating(); stars != 0 {
+		ratingRadio.SetSelected(fmt.Sprint(stars))
+	}
+
+	ratingRadio.OnChanged = func(selected string) {
+
+		stars, err := strconv.Atoi(selected)
+
+		if err != nil {
+			return
+		}
+
+		if recipe.setRating(stars) {
+			onChanged(recipe)
+		}
+	}
+
+	favouriteCheck := widget.NewCheck(tr("Favourite"), nil)
+	favouriteCheck.SetChecked(recipe.isFavourite())
+
+	favouriteCheck.OnChanged = func(checked bool) {
+		if recipe.setFavourite(checked) {
+			onChanged(recipe)
+		}
+	}
+
+	logButton := widget.NewButtonWithIcon(tr("I made this"), theme.ConfirmIcon(), func() {
+		showCookLogDialog(recipe, parent, func(entry CookLogEntry) {
+			if recipe.addCookLogEntry(entry) {
+				onChanged(recipe)
+			}
+		})
+	})
+
+	// Most recent cook log entries
+	cookLogContainer := container.NewVBox()
+	for j, entry := range recipe.userCookLog() {
+
+		if j == 5 {
+			break
+		}
+
+		entryText := entry.Date + " - " + fmt.Sprintf(tr("%d portions"), entry.Portions)
+		if len(entry.Notes) != 0 {
+			entryText += " (" + entry.Notes + ")"
+		}
+
+		entryLabel := widget.NewLabel(entryText)
+		entryLabel.Wrapping = fyne.TextWrapWord
+		cookLogContainer.Add(entryLabel)
+	}
+
+	summaryLabel := widget.NewLabel(ratingSummary(recipe))
+	summaryLabel.Wrapping = fyne.TextWrapWord
+
+	return container.NewVBox(
+		summaryLabel,
+		container.NewHBox(widget.NewLabel(tr("Your rating:")), ratingRadio, favouriteCheck, logButton),
+		cookLogContainer,
+	)
+}
+
+// showCookLogDialog asks for the date, portions and notes of a cooking and passes the new entry to onLogged
+func showCookLogDialog(recipe Recipe, parent fyne.Window, onLogged func(CookLogEntry)) {
+
+	dateEntry := &widget.Entry{Text: time.Now().Format(cookLogDateFormat)}
+	portionEntry := &widget.Entry{Text: fmt.Sprint(recipe.DefaultPortions)}
+	notesEntry := widget.NewMultiLineEntry()
+
+	dateEntry.Validator = func(text string) error {
+		_, err := time.Parse(cookLogDateFormat, text)
+		if err != nil {
+			return errors.New(tr("Date has to be in format YYYY-MM-DD."))
+		}
+		return nil
+	}
+	portionEntry.Validator = validation.NewRegexp(`^[0-9]*[1-9][0-9]*$`, tr("Value has to be a number."))
+
+	formItems := []*widget.FormItem{
+		widget.NewFormItem(tr("Date"), dateEntry),
+		widget.NewFormItem(tr("Portions"), portionEntry),
+		widget.NewFormItem(tr("Notes"), notesEntry),
+	}
+
+	dialog.ShowForm(tr("Log cooking"), tr("Save"), tr("Cancel"), formItems, func(confirmed bool) {
+
+		if !confirmed {
+			return
+		}
+
+		portions, _ := strconv.Atoi(portionEntry.Text)
+
+		onLogged(CookLogEntry{
+			User:     currentUser(),
+			Date:     dateEntry.Text,
+			Portions: portions,
+			Notes:    strings.TrimSpace(notesEntry.Text),
+		})
+
+	}, parent)
+}
@@ -15,6 +15,12 @@ type Ingredient struct {
 	Quantity float64 `json:"quantity"`
 	Unit     string  `json:"unit"`
 	Notes    string  `json:"notes"`
+
+	// Name of the ingredient group, e.g. "For the frosting". Ingredients of a group follow each other in the list.
+	Group string `json:"group,omitempty"`
+
+	// ID of another recipe used as an ingredient, e.g. bechamel sauce in lasagne
+	RecipeId string `json:"recipeid,omitempty"`
 }
 
 type Recipe struct {
@@ -27,10 +33,23 @@ type Recipe struct {
 	PrepTime        int          `json:"preptime"`
 	DefaultPortions int          `json:"defaultportions"`
 	Ingredients     []Ingredient `json:"ingredients"`
-	Image           []byte       `json:"image"`
+	Tags            []string     `json:"tags"`
+	Images          []string     `json:"images"`
+
+	// Small copy of the cover image for result lists; full images are in the images collection
+	Thumbnail []byte `json:"thumbnail"`
+
+	// Recipes saved before images were stored separately have their only image here.
+	// It is not loaded with result lists and is cleared when the recipe is saved again.
+	Image []byte `json:"image"`
+
+	// Changed only through updateFields, so they are omitted when the whole recipe is saved
+	Ratings     []Rating       `json:"ratings,omitempty"`
+	FavouriteOf []string       `json:"favouriteof,omitempty"`
+	CookLog     []CookLogEntry `json:"cooklog,omitempty"`
 }
 
-func (recipe Recipe) addNewRecipe() bool {
+func (recipe Recipe) addNewRecipe() (string, bool) {
 
 	httpClient := http.Client{}
 
@@ -47,7 +66,7 @@ func (recipe Recipe) addNewRecipe() bool {
 	if err != nil {
 		errorDialog := dialog.NewError(err, mainWindow)
 		errorDialog.Show()
-		return false
+		return "", false
 	}
 
 	req, err := http.NewRequest("POST", "https://eu-central-1.aws.data.mongodb-api.com/app/"+credentials["appId"]+"/endpoint/data/v1/action/insertOne", bytes.NewBuffer(jsonBody))
@@ -60,7 +79,7 @@ func (recipe Recipe) addNewRecipe() bool {
 	if err != nil {
 		errorDialog := dialog.NewError(err, mainWindow)
 		errorDialog.Show()
-		return false
+		return "", false
 	}
 
 	rawResponse, err := httpClient.Do(req)
@@ -68,20 +87,28 @@ func (recipe Recipe) addNewRecipe() bool {
 	if err != nil {
 		errorDialog := dialog.NewError(err, mainWindow)
 		errorDialog.Show()
-		return false
+		return "", false
 
 	} else if rawResponse.StatusCode != 201 {
 
 		defer rawResponse.Body.Close()
 		responseBody, _ := ioutil.ReadAll(rawResponse.Body)
-		errorDialog := dialog.NewInformation("Error", "Insert failed: "+fmt.Sprint(rawResponse.StatusCode)+string(responseBody), mainWindow)
+		errorDialog := dialog.NewInformation(tr("Error"), tr("Insert failed: ")+fmt.Sprint(rawResponse.StatusCode)+string(responseBody), mainWindow)
 		errorDialog.Show()
-		return false
+		return "", false
 
 	} else {
-		successDialog := dialog.NewInformation("OK", "Recipe succesfully added!", mainWindow)
+		defer rawResponse.Body.Close()
+
+		// ID of the new recipe, for adding it to the search index
+		var response struct {
+			InsertedId string `json:"insertedId"`
+		}
+		json.NewDecoder(rawResponse.Body).Decode(&response)
+
+		successDialog := dialog.NewInformation(tr("OK"), tr("Recipe succesfully added!"), mainWindow)
 		successDialog.Show()
-		return true
+		return response.InsertedId, true
 	}
 
 }
@@ -137,13 +164,31 @@ func (recipe Recipe) updateRecipe(documentId string) bool {
 
 		defer rawResponse.Body.Close()
 		responseBody, _ := ioutil.ReadAll(rawResponse.Body)
-		errorDialog := dialog.NewInformation("Error", "Update failed: "+fmt.Sprint(rawResponse.StatusCode)+string(responseBody), mainWindow)
+		errorDialog := dialog.NewInformation(tr("Error"), tr("Update failed: ")+fmt.Sprint(rawResponse.StatusCode)+string(responseBody), mainWindow)
 		errorDialog.Show()
 		return false
 
 	} else {
-		successDialog := dialog.NewInformation("OK", "Recipe succesfully updated!", mainWindow)
+		successDialog := dialog.NewInformation(tr("OK"), tr("Recipe succesfully updated!"), mainWindow)
 		successDialog.Show()
 		return true
 	}
 }
+
+// updateFields applies a MongoDB update document (e.g. $set or $push) to the stored recipe
+func (recipe Recipe) updateFields(update map[string]interface{}) bool {
+
+	body := map[string]interface{}{
+		"collection": credentials["collection"],
+		"filter":     map[string]map[string]string{"_id": {"$oid": recipe.Id}},
+		"update":     update,
+	}
+
+	if err := callDataAPI("updateOne", body, nil); err != nil {
+		errorDialog := dialog.NewError(err, mainWindow)
+		errorDialog.Show()
+		return false
+	}
+
+	return true
+}
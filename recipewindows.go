@@ -0,0 +1,178 @@
+package main
+
+import (
+	"math"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+// RecipeWindow shows a recipe in its own window, so several recipes can be followed while cooking.
+// Every window handles its events separately, so changes to the main window are passed to its event queue and back.
+type RecipeWindow struct {
+	window fyne.Window
+	recipe Recipe
+}
+
+// Recipes opened in their own windows: recipe ID -> window
+var recipeWindows = map[string]*RecipeWindow{}
+
+// Size of the first recipe window, later ones open with the size of the last one closed
+var recipeWindowSize = fyne.NewSize(600, 800)
+
+// openRecipeWindow shows a recipe in its own window, or brings its window to front if it is already open
+func openRecipeWindow(recipe Recipe) {
+
+	if isMobile {
+		return
+	}
+
+	if recipeWindow, exists := recipeWindows[recipe.Id]; exists {
+		recipeWindow.window.RequestFocus()
+		return
+	}
+
+	preferences := mainApp.Preferences()
+
+	recipeWindow := &RecipeWindow{window: mainApp.NewWindow(recipe.Title), recipe: recipe}
+	recipeWindows[recipe.Id] = recipeWindow
+
+	width := preferences.FloatWithFallback("recipeWindowWidth", float64(recipeWindowSize.Width))
+	height := preferences.FloatWithFallback("recipeWindowHeight", float64(recipeWindowSize.Height))
+	recipeWindow.window.Resize(fyne.NewSize(float32(width), float32(height)))
+
+	recipeWindow.window.SetOnClosed(func() {
+		size := recipeWindow.window.Canvas().Size()
+		preferences.SetFloat("recipeWindowWidth", float64(size.Width))
+		preferences.SetFloat("recipeWindowHeight", float64(size.Height))
+
+		runOnUIThread(mainWindow, func() { delete(recipeWindows, recipe.Id) })
+	})
+
+	recipeWindow.display()
+	recipeWindow.window.Show()
+}
+
+func (w *RecipeWindow) display() {
+
+	// Ratings changed in the window are also shown in the main window
+	onRated := func(updated Recipe) {
+		w.recipe = updated
+		w.display()
+		runOnUIThread(mainWindow, func() { updateDisplayedRecipe(updated) })
+	}
+
+	detailsContainer := createRecipeDetails(w.recipe, w.window, onRated, w.display)
+
+	// Recipes are edited in the main window, which updates this one when the recipe is saved
+	editRecipeButton := widget.NewButtonWithIcon(tr("Edit recipe"), theme.DocumentCreateIcon(), func() {
+		recipe := w.recipe
+
+		runOnUIThread(mainWindow, func() {
+			mainWindow.RequestFocus()
+			recipeEntry(recipe, "edit")
+		})
+	})
+
+	detailsContainer.Add(container.NewHBox(layout.NewSpacer(), editRecipeButton, layout.NewSpacer()))
+
+	w.window.SetTitle(w.recipe.Title)
+	w.window.SetContent(container.NewVScroll(detailsContainer))
+}
+
+// updateRecipeWindow redisplays the window of a recipe that was changed in the main window
+func updateRecipeWindow(recipe Recipe) {
+
+	if recipeWindow, exists := recipeWindows[recipe.Id]; exists {
+		runOnUIThread(recipeWindow.window, func() {
+			recipeWindow.recipe = recipe
+			recipeWindow.display()
+		})
+	}
+}
+
+// updateSavedRecipeWindows redisplays the window of a saved recipe and windows of recipes that use it,
+// directly or through other recipes, with recipes from the updated search index
+func updateSavedRecipeWindows(savedId string) {
+
+	for id := range recipeWindows {
+
+		recipe, exists := findRecipe(id)
+		if !exists {
+			continue
+		}
+
+		if _, references := findReferenceCycle(savedId, recipe.Ingredients); id == savedId || references {
+			updateRecipeWindow(recipe)
+		}
+	}
+}
+
+// updateDisplayedRecipe replaces a recipe changed in its own window in the main window results and details,
+// without running the query again
+func updateDisplayedRecipe(recipe Recipe) {
+
+	displayedIndex := -1
+
+	for j := range currentRecipes {
+		if currentRecipes[j].Id == recipe.Id {
+			currentRecipes[j] = recipe
+			displayedIndex = j
+		}
+	}
+
+	if displayedIndex >= 0 && currentScreen != nil && currentScreen.Kind == "recipe" && currentScreen.RecipeId == recipe.Id {
+		allPages := int(math.Ceil(float64(currentCount) / float64(config.resultsPerPage)))
+		displayRecipeDetails(displayedIndex, allPages, currentScreen.Title)
+		return
+	}
+
+	// Rating summaries in results beside other details
+	if showsCurrentResults() {
+		resultsPane.Refresh()
+	}
+}
+
+// createOpenWindowButton creates a button that opens a recipe in its own window
+func createOpenWindowButton(recipe Recipe) *widget.Button {
+	return widget.NewButtonWithIcon(tr("Open in new window"), theme.ViewFullScreenIcon(), func() { openRecipeWindow(recipe) })
+}
+
+// showRecipeMenu shows a menu for opening a recipe from results in its own window
+func showRecipeMenu(recipe Recipe, position fyne.Position) {
+
+	if isMobile {
+		return
+	}
+
+	menu := fyne.NewMenu("", fyne.NewMenuItem(tr("Open in new window"), func() { openRecipeWindow(recipe) }))
+	widget.ShowPopUpMenuAtPosition(menu, mainWindow.Canvas(), position)
+}
+
+// RecipeMenuArea shows the recipe menu when its content is tapped with the secondary mouse button.
+// Primary taps pass through to the list row the area is in.
+type RecipeMenuArea struct {
+	widget.BaseWidget
+
+	Content *fyne.Container
+	Recipe  Recipe
+}
+
+func newRecipeMenuArea(content *fyne.Container) *RecipeMenuArea {
+
+	area := &RecipeMenuArea{Content: content}
+	area.ExtendBaseWidget(area)
+
+	return area
+}
+
+func (a *RecipeMenuArea) CreateRenderer() fyne.WidgetRenderer {
+	return widget.NewSimpleRenderer(a.Content)
+}
+
+func (a *RecipeMenuArea) TappedSecondary(event *fyne.PointEvent) {
+	showRecipeMenu(a.Recipe, event.AbsolutePosition)
+}
@@ -0,0 +1,307 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/driver/desktop"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	"golang.org/x/exp/maps"
+)
+
+// Results are shown as a list or as a grid of cards
+const (
+	resultsViewList = "list"
+	resultsViewGrid = "grid"
+)
+
+// Results are split into pages or loaded while the user scrolls
+const (
+	resultsPagingPages  = "pages"
+	resultsPagingScroll = "scroll"
+)
+
+var resultsPagingNames = map[string]string{
+	resultsPagingPages:  "Pages",
+	resultsPagingScroll: "Infinite scroll",
+}
+
+// Size of recipe cards in the grid view
+var recipeCardSize = fyne.NewSize(200, 240)
+
+// Distance from the end of the grid at which the next results are loaded
+const loadMoreDistance = 200
+
+// Set while the next results are loaded in infinite scroll mode
+var loadingMoreResults bool
+
+// Displayed list of results, nil when results are shown as a grid
+var resultsList *widget.List
+
+// recipeSummary joins the rating, allergen and cost summaries of a recipe
+func recipeSummary(recipe Recipe) string {
+
+	summaryParts := []string{}
+	for _, part := range []string{ratingSummary(recipe), allergenSummary(recipe), costSummary(recipe)} {
+		if len(part) != 0 {
+			summaryParts = append(summaryParts, part)
+		}
+	}
+
+	return strings.Join(summaryParts, " | ")
+}
+
+// newRecipeImage shows the thumbnail of a recipe. Recipes without one show the placeholder until their cover image is loaded.
+func newRecipeImage(recipe Recipe, size fyne.Size) *canvas.Image {
+
+	if len(recipe.Thumbnail) != 0 {
+		recipeImage := canvas.NewImageFromResource(fyne.NewStaticResource("img"+recipe.Id, recipe.Thumbnail))
+		recipeImage.FillMode = canvas.ImageFillContain
+		recipeImage.SetMinSize(size)
+		return recipeImage
+	}
+
+	recipeImage := canvas.NewImageFromResource(resourcePlaceholderJpg)
+	recipeImage.FillMode = canvas.ImageFillContain
+	recipeImage.SetMinSize(size)
+
+	go func() {
+		if coverImage := listImage(recipe); len(coverImage) != 0 {
+			recipeImage.Resource = fyne.NewStaticResource("img"+recipe.Id, coverImage)
+			recipeImage.Refresh()
+		}
+	}()
+
+	return recipeImage
+}
+
+// loadMoreResults appends the next page of results to the current ones and calls onLoaded when they are added
+func loadMoreResults(onLoaded func()) {
+
+	if loadingMoreResults || len(currentRecipes) >= currentCount {
+		return
+	}
+
+	loadingMoreResults = true
+
+	// The background query only uses this copy, current results are changed back on the UI thread
+	query := maps.Clone(currentQuery)
+	offset := len(currentRecipes)
+
+	go func() {
+
+		moreRecipes, totalCount := getResults(query, offset)
+
+		runOnUIThread(mainWindow, func() {
+
+			loadingMoreResults = false
+
+			// Results of a query the user already left, or of a page that was reloaded, are not needed
+			if !maps.Equal(query, currentQuery) || offset != len(currentRecipes) {
+				return
+			}
+
+			currentRecipes = append(currentRecipes, moreRecipes...)
+			currentCount = totalCount
+
+			// Results that shrank in the meantime would be requested again and again
+			if len(moreRecipes) == 0 {
+				currentCount = len(currentRecipes)
+			}
+
+			onLoaded()
+		})
+	}()
+}
+
+// createRecipeList shows the current results as a list of rows with a thumbnail, title and summary
+func createRecipeList(onSelected func(id int)) fyne.CanvasObject {
+
+	// Placeholder image for recipes that don't have one
+	imagePlaceholder := canvas.NewImageFromResource(resourcePlaceholderJpg)
+	imagePlaceholder.FillMode = canvas.ImageFillContain
+	imagePlaceholder.SetMinSize(fyne.NewSize(50, 50))
+
+	var recipeList *widget.List
+
+	recipeList = widget.NewList(
+		func() int {
+			return len(currentRecipes)
+		},
+		func() fyne.CanvasObject {
+			// Second label leaves room for the rating and allergen summary
+			return newRecipeMenuArea(container.NewHBox(imagePlaceholder, container.NewVBox(widget.NewLabel("placeholder"), widget.NewLabel("placeholder"))))
+		},
+		func(i widget.ListItemID, o fyne.CanvasObject) {
+			o.(*RecipeMenuArea).Recipe = currentRecipes[i]
+
+			row := o.(*RecipeMenuArea).Content
+			row.RemoveAll()
+			row.Add(newRecipeImage(currentRecipes[i], fyne.NewSize(50, 50)))
+
+			rowText := container.NewVBox(layout.NewSpacer(), widget.NewLabel(currentRecipes[i].Title))
+
+			if summary := recipeSummary(currentRecipes[i]); len(summary) != 0 {
+				summaryLabel := widget.NewLabel(summary)
+				summaryLabel.TextStyle = fyne.TextStyle{Italic: true}
+				rowText.Add(summaryLabel)
+			}
+
+			rowText.Add(layout.NewSpacer())
+			row.Add(rowText)
+
+			// The next results are loaded when the last row is shown
+			if config.resultsPaging == resultsPagingScroll && i == len(currentRecipes)-1 {
+				loadMoreResults(recipeList.Refresh)
+			}
+		})
+
+	recipeList.OnSelected = func(id widget.ListItemID) { onSelected(id) }
+	resultsList = recipeList
+
+	return recipeList
+}
+
+// RecipeCard shows a recipe in the card grid and opens it when tapped
+type RecipeCard struct {
+	widget.Card
+
+	Recipe   Recipe
+	OnTapped func()
+}
+
+func newRecipeCard(recipe Recipe, onTapped func()) *RecipeCard {
+
+	titleLabel := widget.NewLabelWithStyle(recipe.Title, fyne.TextAlignCenter, fyne.TextStyle{Bold: true})
+	titleLabel.Wrapping = fyne.TextTruncate
+
+	summaryLabel := widget.NewLabelWithStyle(recipeSummary(recipe), fyne.TextAlignCenter, fyne.TextStyle{Italic: true})
+	summaryLabel.Wrapping = fyne.TextTruncate
+
+	card := &RecipeCard{Recipe: recipe, OnTapped: onTapped}
+	card.Content = container.NewVBox(newRecipeImage(recipe, fyne.NewSize(recipeCardSize.Width-theme.Padding()*4, 130)), titleLabel, summaryLabel)
+	card.ExtendBaseWidget(card)
+
+	return card
+}
+
+func (c *RecipeCard) Tapped(*fyne.PointEvent) {
+
+	if c.OnTapped != nil {
+		c.OnTapped()
+	}
+}
+
+func (c *RecipeCard) TappedSecondary(event *fyne.PointEvent) {
+	showRecipeMenu(c.Recipe, event.AbsolutePosition)
+}
+
+func (c *RecipeCard) Cursor() desktop.Cursor {
+	return desktop.PointerCursor
+}
+
+// createRecipeGrid shows the current results as a grid of cards with large images
+func createRecipeGrid(onSelected func(id int)) fyne.CanvasObject {
+
+	grid := container.NewGridWrap(recipeCardSize)
+	resultsList = nil
+
+	addCards := func() {
+		for id := len(grid.Objects); id < len(currentRecipes); id++ {
+			recipeId := id
+			grid.Add(newRecipeCard(currentRecipes[id], func() { onSelected(recipeId) }))
+		}
+	}
+
+	addCards()
+
+	if config.resultsPaging != resultsPagingScroll {
+		return container.NewVScroll(grid)
+	}
+
+	// Cards are added when the user scrolls near the end, or with the button when all results fit on the screen
+	moreButton := widget.NewButtonWithIcon(tr("Show more"), theme.MoreVerticalIcon(), nil)
+
+	updateMoreButton := func() {
+		if len(currentRecipes) >= currentCount {
+			moreButton.Hide()
+		}
+	}
+
+	showMore := func() {
+		loadMoreResults(func() {
+			addCards()
+			updateMoreButton()
+		})
+	}
+
+	moreButton.OnTapped = showMore
+	updateMoreButton()
+
+	gridScroll := container.NewVScroll(container.NewVBox(grid, container.NewCenter(moreButton)))
+	gridScroll.OnScrolled = func(offset fyne.Position) {
+		if offset.Y+gridScroll.Size().Height >= gridScroll.Content.MinSize().Height-loadMoreDistance {
+			showMore()
+		}
+	}
+
+	return gridScroll
+}
+
+// createViewToggle creates a button that switches results between the list and the card grid
+func createViewToggle(onChanged func()) fyne.CanvasObject {
+
+	icon := theme.GridIcon()
+	nextView := resultsViewGrid
+
+	if config.resultsView == resultsViewGrid {
+		icon = theme.ListIcon()
+		nextView = resultsViewList
+	}
+
+	return &widget.Button{Icon: icon, OnTapped: func() {
+		config.resultsView = nextView
+		mainApp.Preferences().SetString("resultsView", nextView)
+		onChanged()
+	}}
+}
+
+// createPager creates buttons for the first, previous, next and last page and an entry for jumping to a page
+func createPager(allPages int) fyne.CanvasObject {
+
+	firstButton := &widget.Button{Icon: theme.MediaSkipPreviousIcon(), OnTapped: func() { showResultsPage(1) }}
+	previousButton := &widget.Button{Icon: theme.NavigateBackIcon(), OnTapped: func() { showResultsPage(currentPage - 1) }}
+	nextButton := &widget.Button{Icon: theme.NavigateNextIcon(), OnTapped: func() { showResultsPage(currentPage + 1) }}
+	lastButton := &widget.Button{Icon: theme.MediaSkipNextIcon(), OnTapped: func() { showResultsPage(allPages) }}
+
+	if currentPage <= 1 {
+		firstButton.Disable()
+		previousButton.Disable()
+	}
+
+	if currentPage >= allPages {
+		nextButton.Disable()
+		lastButton.Disable()
+	}
+
+	pageLabel := widget.NewLabel(fmt.Sprintf(tr("Page %d of %d"), currentPage, allPages))
+
+	jumpEntry := newFormEntry(tr("Page"))
+	jumpEntry.OnSubmitted = func(text string) {
+		if page, err := strconv.Atoi(strings.TrimSpace(text)); err == nil {
+			showResultsPage(page)
+		}
+	}
+
+	jumpButton := &widget.Button{Text: tr("Go"), OnTapped: func() { jumpEntry.OnSubmitted(jumpEntry.Text) }}
+
+	jumpContainer := container.NewGridWrap(fyne.NewSize(70, jumpEntry.MinSize().Height), jumpEntry)
+
+	return container.NewHBox(layout.NewSpacer(), firstButton, previousButton, pageLabel, nextButton, lastButton, jumpContainer, jumpButton, layout.NewSpacer())
+}
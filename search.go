@@ -0,0 +1,456 @@
+package main
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+// Matches in titles count more than matches in ingredients, tags or descriptions
+const (
+	titleWeight       = 3.0
+	ingredientWeight  = 2.0
+	descriptionWeight = 1.0
+)
+
+// BM25 ranking parameters
+const (
+	bm25K1 = 1.2
+	bm25B  = 0.75
+)
+
+// File in app storage with the last known copy of all recipes, used for searching offline
+const searchCacheFile = "recipeCache.json"
+
+var localIndex *SearchIndex
+
+var stopWords = map[string]bool{
+	"a": true, "an": true, "and": true, "the": true, "of": true, "with": true,
+	"in": true, "on": true, "for": true, "to": true, "or": true, "from": true,
+}
+
+type posting struct {
+	doc       int
+	frequency float64
+}
+
+// SearchIndex is an inverted index over recipe titles, ingredients, tags and descriptions
+type SearchIndex struct {
+	recipes       []Recipe
+	positions     map[string]int // recipe ID -> position in recipes
+	postings      map[string][]posting
+	docLengths    []float64
+	averageLength float64
+}
+
+// loadSearchIndex builds the local search index from all recipes in the collection in the background.
+// Until it is ready, recipes are found in the database. If the database cannot be reached,
+// the cached copy from the last successful load is used.
+func loadSearchIndex() {
+
+	go func() {
+
+		recipes, err := getAllRecipes()
+
+		if err != nil {
+			recipes = []Recipe{}
+			loadLocalJSON(searchCacheFile, &recipes)
+
+		} else {
+			saveLocalJSON(searchCacheFile, recipes)
+		}
+
+		index := newSearchIndex(recipes)
+
+		runOnUIThread(mainWindow, func() { localIndex = index })
+	}()
+}
+
+// updateSearchIndex reloads a saved recipe into the local search index, without loading all recipes again
+func updateSearchIndex(id string) {
+
+	if localIndex == nil {
+		return
+	}
+
+	for _, recipe := range getRecipesByIds([]string{id}) {
+		localIndex = localIndex.withRecipe(recipe)
+	}
+
+	saveLocalJSON(searchCacheFile, localIndex.recipes)
+}
+
+func newSearchIndex(recipes []Recipe) *SearchIndex {
+
+	index := &SearchIndex{
+		recipes:    recipes,
+		positions:  map[string]int{},
+		postings:   map[string][]posting{},
+		docLengths: make([]float64, len(recipes)),
+	}
+
+	for i, recipe := range recipes {
+		index.positions[recipe.Id] = i
+		index.addPostings(i)
+	}
+
+	index.updateAverageLength()
+
+	return index
+}
+
+// recipe returns a recipe of the index by ID
+func (index *SearchIndex) recipe(id string) (Recipe, bool) {
+
+	if position, exists := index.positions[id]; exists {
+		return index.recipes[position], true
+	}
+
+	return Recipe{}, false
+}
+
+// withRecipe returns a copy of the index with a recipe replaced, or added if it is new.
+// The index itself is not changed, because more results are searched for in the background.
+func (index *SearchIndex) withRecipe(recipe Recipe) *SearchIndex {
+
+	updated := &SearchIndex{
+		recipes:    slices.Clone(index.recipes),
+		positions:  maps.Clone(index.positions),
+		postings:   maps.Clone(index.postings),
+		docLengths: slices.Clone(index.docLengths),
+	}
+
+	doc, exists := updated.positions[recipe.Id]
+
+	if exists {
+		updated.removePostings(doc)
+		updated.recipes[doc] = recipe
+
+	} else {
+		doc = len(updated.recipes)
+		updated.recipes = append(updated.recipes, recipe)
+		updated.docLengths = append(updated.docLengths, 0)
+		updated.positions[recipe.Id] = doc
+	}
+
+	updated.addPostings(doc)
+	updated.updateAverageLength()
+
+	return updated
+}
+
+// recipeTerms returns the terms of a recipe with their frequencies, weighted by the field they are in
+func recipeTerms(recipe Recipe) map[string]float64 {
+
+	termFrequencies := map[string]float64{}
+
+	addTerms := func(text string, weight float64) {
+		for _, term := range tokenize(text) {
+			termFrequencies[term] += weight
+		}
+	}
+
+	addTerms(recipe.Title, titleWeight)
+	for _, ingr := range recipe.Ingredients {
+		addTerms(ingr.Name, ingredientWeight)
+	}
+	for _, tag := range recipe.Tags {
+		addTerms(tag, ingredientWeight)
+	}
+	addTerms(recipe.Description, descriptionWeight)
+
+	return termFrequencies
+}
+
+func (index *SearchIndex) addPostings(doc int) {
+
+	for term, frequency := range recipeTerms(index.recipes[doc]) {
+		index.postings[term] = append(index.postings[term], posting{doc: doc, frequency: frequency})
+		index.docLengths[doc] += frequency
+	}
+}
+
+func (index *SearchIndex) removePostings(doc int) {
+
+	for term := range recipeTerms(index.recipes[doc]) {
+
+		index.postings[term] = slices.DeleteFunc(slices.Clone(index.postings[term]), func(p posting) bool { return p.doc == doc })
+
+		if len(index.postings[term]) == 0 {
+			delete(index.postings, term)
+		}
+	}
+
+	index.docLengths[doc] = 0
+}
+
+func (index *SearchIndex) updateAverageLength() {
+
+	totalLength := 0.0
+	for _, length := range index.docLengths {
+		totalLength += length
+	}
+
+	index.averageLength = 0
+	if len(index.recipes) > 0 {
+		index.averageLength = totalLength / float64(len(index.recipes))
+	}
+}
+
+// search returns all recipes matching the query, best matches first
+func (index *SearchIndex) search(query string) []Recipe {
+
+	if index == nil || len(index.recipes) == 0 {
+		return []Recipe{}
+	}
+
+	scores := map[int]float64{}
+
+	for _, queryTerm := range tokenize(query) {
+
+		// Each query term only counts once per recipe, using its best matching index term
+		bestScores := map[int]float64{}
+
+		for term, similarity := range index.matchingTerms(queryTerm) {
+
+			idf := index.inverseDocumentFrequency(term)
+
+			for _, p := range index.postings[term] {
+
+				lengthNorm := bm25K1 * (1 - bm25B + bm25B*index.docLengths[p.doc]/index.averageLength)
+				score := similarity * idf * p.frequency * (bm25K1 + 1) / (p.frequency + lengthNorm)
+
+				if score > bestScores[p.doc] {
+					bestScores[p.doc] = score
+				}
+			}
+		}
+
+		for doc, score := range bestScores {
+			scores[doc] += score
+		}
+	}
+
+	ranked := make([]int, 0, len(scores))
+	for doc := range scores {
+		ranked = append(ranked, doc)
+	}
+
+	sort.Slice(ranked, func(a, b int) bool {
+		if scores[ranked[a]] == scores[ranked[b]] {
+			return ranked[a] < ranked[b]
+		}
+		return scores[ranked[a]] > scores[ranked[b]]
+	})
+
+	results := make([]Recipe, len(ranked))
+	for i, doc := range ranked {
+		results[i] = index.recipes[doc]
+	}
+
+	return results
+}
+
+// searchPage returns one page of search results and the count of all matched recipes
+func (index *SearchIndex) searchPage(query string, offset int, perPage int) (results []Recipe, totalCount int) {
+
+	allResults := withoutExcludedAllergens(index.search(query))
+
+	if offset >= len(allResults) {
+		return []Recipe{}, len(allResults)
+	}
+
+	end := offset + perPage
+	if end > len(allResults) {
+		end = len(allResults)
+	}
+
+	return allResults[offset:end], len(allResults)
+}
+
+// matchingTerms returns index terms that match a query term exactly, by prefix or with a typo,
+// together with a similarity factor between 0 and 1
+func (index *SearchIndex) matchingTerms(queryTerm string) map[string]float64 {
+
+	matches := map[string]float64{}
+
+	if _, exists := index.postings[queryTerm]; exists {
+		matches[queryTerm] = 1
+	}
+
+	// Longer words tolerate more typos
+	maxDistance := 0
+	if len(queryTerm) >= 8 {
+		maxDistance = 2
+
+	} else if len(queryTerm) >= 4 {
+		maxDistance = 1
+	}
+
+	for term := range index.postings {
+
+		if term == queryTerm {
+			continue
+		}
+
+		if len(queryTerm) >= 3 && strings.HasPrefix(term, queryTerm) {
+			matches[term] = 0.8
+			continue
+		}
+
+		if maxDistance > 0 {
+			if distance := editDistance(queryTerm, term, maxDistance); distance <= maxDistance {
+				matches[term] = 1 - 0.3*float64(distance)
+			}
+		}
+	}
+
+	return matches
+}
+
+func (index *SearchIndex) inverseDocumentFrequency(term string) float64 {
+
+	docCount := float64(len(index.recipes))
+	termCount := float64(len(index.postings[term]))
+
+	return math.Log(1 + (docCount-termCount+0.5)/(termCount+0.5))
+}
+
+// tokenize splits text into accent-folded, lowercase and stemmed terms
+func tokenize(text string) []string {
+
+	words := strings.FieldsFunc(strings.ToLower(foldAccents(text)), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	terms := []string{}
+
+	for _, word := range words {
+		if len(word) < 2 || stopWords[word] {
+			continue
+		}
+		terms = append(terms, stem(word))
+	}
+
+	return terms
+}
+
+// foldAccents removes diacritics, so that "jalapeño" matches "jalapeno"
+func foldAccents(text string) string {
+
+	folding := transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+
+	folded, _, err := transform.String(folding, text)
+
+	if err != nil {
+		return text
+	}
+
+	return folded
+}
+
+// stem strips common English suffixes so that different forms of a word share one term
+func stem(word string) string {
+
+	if utf8.RuneCountInString(word) <= 3 {
+		return word
+	}
+
+	// Plurals
+	switch {
+	case strings.HasSuffix(word, "ies") && len(word) > 4:
+		word = strings.TrimSuffix(word, "ies") + "y"
+
+	case strings.HasSuffix(word, "oes"):
+		word = strings.TrimSuffix(word, "es")
+
+	case strings.HasSuffix(word, "sses"):
+		word = strings.TrimSuffix(word, "es")
+
+	case strings.HasSuffix(word, "s") && !strings.HasSuffix(word, "ss") && !strings.HasSuffix(word, "us"):
+		word = strings.TrimSuffix(word, "s")
+	}
+
+	// Verb and adverb forms
+	for _, suffix := range []string{"ing", "ed", "ly"} {
+
+		if strings.HasSuffix(word, suffix) && utf8.RuneCountInString(word)-len(suffix) >= 3 {
+			word = strings.TrimSuffix(word, suffix)
+
+			// "chopped" -> "chop"
+			letters := []rune(word)
+			last := len(letters) - 1
+			if last > 0 && letters[last] == letters[last-1] && !strings.ContainsRune("aeiouslzf", letters[last]) {
+				word = string(letters[:last])
+			}
+			break
+		}
+	}
+
+	// "bake", "baked" and "baking" all become "bak"
+	if utf8.RuneCountInString(word) > 3 {
+		word = strings.TrimSuffix(word, "e")
+	}
+
+	return word
+}
+
+// editDistance returns the Levenshtein distance between two words, or maxDistance+1 if it is larger than maxDistance
+func editDistance(a string, b string, maxDistance int) int {
+
+	first, second := []rune(a), []rune(b)
+
+	if int(math.Abs(float64(len(first)-len(second)))) > maxDistance {
+		return maxDistance + 1
+	}
+
+	previous := make([]int, len(second)+1)
+	current := make([]int, len(second)+1)
+
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(first); i++ {
+
+		current[0] = i
+		rowMinimum := current[0]
+
+		for j := 1; j <= len(second); j++ {
+
+			cost := 1
+			if first[i-1] == second[j-1] {
+				cost = 0
+			}
+
+			current[j] = previous[j-1] + cost
+			if previous[j]+1 < current[j] {
+				current[j] = previous[j] + 1
+			}
+			if current[j-1]+1 < current[j] {
+				current[j] = current[j-1] + 1
+			}
+
+			if current[j] < rowMinimum {
+				rowMinimum = current[j]
+			}
+		}
+
+		// No need to continue if the distance is already too large
+		if rowMinimum > maxDistance {
+			return maxDistance + 1
+		}
+
+		previous, current = current, previous
+	}
+
+	return previous[len(second)]
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func testRecipes() []Recipe {
+	return []Recipe{
+		{Id: "1", Title: "Tomato soup", Ingredients: []Ingredient{{Name: "tomatoes"}, {Name: "onion"}}, Description: "Simple soup."},
+		{Id: "2", Title: "Pasta with basil", Ingredients: []Ingredient{{Name: "pasta"}, {Name: "tomato"}, {Name: "basil"}}, Description: "Quick dinner."},
+		{Id: "3", Title: "Pancakes", Ingredients: []Ingredient{{Name: "flour"}, {Name: "milk"}, {Name: "eggs"}}, Description: "Sweet or savoury."},
+	}
+}
+
+func resultIds(results []Recipe) []string {
+
+	ids := []string{}
+	for _, recipe := range results {
+		ids = append(ids, recipe.Id)
+	}
+
+	return ids
+}
+
+func TestSearchRanking(t *testing.T) {
+
+	index := newSearchIndex(testRecipes())
+
+	tests := []struct {
+		query string
+		ids   []string
+	}{
+		// Title matches rank above ingredient matches
+		{"tomato", []string{"1", "2"}},
+		{"pancake", []string{"3"}},
+		// Typos and accents are tolerated
+		{"pancaks", []string{"3"}},
+		{"básil", []string{"2"}},
+		{"chocolate", []string{}},
+	}
+
+	for _, test := range tests {
+		if ids := resultIds(index.search(test.query)); !reflect.DeepEqual(ids, test.ids) {
+			t.Errorf("search(%q) = %v, want %v", test.query, ids, test.ids)
+		}
+	}
+}
+
+func TestSearchIndexWithRecipe(t *testing.T) {
+
+	recipes := testRecipes()
+	index := newSearchIndex(recipes[:2])
+
+	changed := recipes[0]
+	changed.Title = "Onion soup"
+
+	updated := index.withRecipe(changed).withRecipe(recipes[2])
+
+	// An updated index searches like one built from scratch
+	rebuilt := newSearchIndex([]Recipe{changed, recipes[1], recipes[2]})
+
+	for _, query := range []string{"tomato", "onion", "soup", "pancake"} {
+		if ids, want := resultIds(updated.search(query)), resultIds(rebuilt.search(query)); !reflect.DeepEqual(ids, want) {
+			t.Errorf("search(%q) after update = %v, want %v", query, ids, want)
+		}
+	}
+
+	if recipe, found := updated.recipe("1"); !found || recipe.Title != "Onion soup" {
+		t.Errorf("recipe(\"1\") = %q, %v, want the changed recipe", recipe.Title, found)
+	}
+
+	// The original index is not changed
+	if ids := resultIds(index.search("onion")); !reflect.DeepEqual(ids, []string{"1"}) {
+		t.Errorf("search(\"onion\") on the original index = %v, want [1]", ids)
+	}
+
+	if _, found := index.recipe("3"); found {
+		t.Error("recipe added to the original index")
+	}
+}
+
+func TestEditDistance(t *testing.T) {
+
+	tests := []struct {
+		a, b        string
+		maxDistance int
+		distance    int
+	}{
+		{"tomato", "tomato", 1, 0},
+		{"tomato", "tomata", 1, 1},
+		{"basil", "bsail", 2, 2},
+		{"basil", "parsley", 2, 3},
+		{"cake", "pancake", 1, 2},
+	}
+
+	for _, test := range tests {
+		if distance := editDistance(test.a, test.b, test.maxDistance); distance != test.distance {
+			t.Errorf("editDistance(%q, %q, %d) = %d, want %d", test.a, test.b, test.maxDistance, distance, test.distance)
+		}
+	}
+}
+
+func TestStem(t *testing.T) {
+
+	tests := []struct {
+		word string
+		stem string
+	}{
+		{"chopped", "chop"},
+		{"baking", "bak"},
+		{"eggs", "egg"},
+		{"tomatoes", "tomato"},
+		{"dressed", "dress"},
+		{"smørrebrød", "smørrebrød"},
+		{"małłed", "mał"},
+		{"große", "groß"},
+	}
+
+	for _, test := range tests {
+		if stem := stem(test.word); stem != test.stem {
+			t.Errorf("stem(%q) = %q, want %q", test.word, stem, test.stem)
+		}
+	}
+}
@@ -0,0 +1,328 @@
+package main
+
+import (
+	"math"
+	"sort"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/driver/desktop"
+	"fyne.io/fyne/v2/widget"
+)
+
+// Names of shortcuts added to the window. Entries pass these on instead of handling them.
+var appShortcuts = map[string]bool{}
+
+// Submits the recipe entry that is displayed
+var submitRecipeEntry func()
+
+// Tells if the recipe entry that is displayed has changes that were not submitted
+var recipeEntryChanged func() bool
+
+// leaveRecipeEntry runs leave right away, unless the recipe entry is displayed with unsubmitted changes.
+// Then the changes are discarded only after the user confirms it.
+func leaveRecipeEntry(leave func()) {
+
+	if currentScreen == nil || currentScreen.Kind != "entry" || recipeEntryChanged == nil || !recipeEntryChanged() {
+		leave()
+		return
+	}
+
+	dialog.ShowConfirm(tr("Discard changes?"), tr("The recipe has changes that were not submitted."), func(confirmed bool) {
+		if confirmed {
+			leave()
+		}
+	}, mainWindow)
+}
+
+func addShortcut(keyName fyne.KeyName, modifier fyne.KeyModifier, handler func()) {
+
+	shortcut := &desktop.CustomShortcut{KeyName: keyName, Modifier: modifier}
+	appShortcuts[shortcut.ShortcutName()] = true
+
+	mainWindow.Canvas().AddShortcut(shortcut, func(fyne.Shortcut) { handler() })
+}
+
+// addAppShortcuts adds keyboard shortcuts for searching, recipes, history and pages
+func addAppShortcuts() {
+
+	// Escape and page keys also work on mobile devices with a keyboard, where Fyne 2.3.5 does not
+	// dispatch shortcuts. It reports the Android back key as an unknown key and does not report
+	// mouse back and forward buttons, so those are left to the Back and Forward buttons.
+	mainWindow.Canvas().SetOnTypedKey(func(key *fyne.KeyEvent) { typedAppKey(key) })
+
+	if isMobile {
+		return
+	}
+
+	addShortcut(fyne.KeyF, fyne.KeyModifierShortcutDefault, func() { leaveRecipeEntry(focusSearch) })
+	addShortcut(fyne.KeyN, fyne.KeyModifierShortcutDefault, func() { leaveRecipeEntry(func() { recipeEntry(Recipe{}, "new") }) })
+	addShortcut(fyne.KeyE, fyne.KeyModifierShortcutDefault, editDisplayedRecipe)
+	addShortcut(fyne.KeyK, fyne.KeyModifierShortcutDefault, showCommandPalette)
+
+	addShortcut(fyne.KeyS, fyne.KeyModifierShortcutDefault, func() {
+		if currentScreen != nil && currentScreen.Kind == "entry" && submitRecipeEntry != nil {
+			submitRecipeEntry()
+		}
+	})
+
+	// Back and forward like in web browsers
+	addShortcut(fyne.KeyLeft, fyne.KeyModifierAlt, func() { leaveRecipeEntry(goBack) })
+	addShortcut(fyne.KeyRight, fyne.KeyModifierAlt, func() { leaveRecipeEntry(goForward) })
+}
+
+// isAppShortcut tells if a shortcut is one of the app and not for the focused widget
+func isAppShortcut(shortcut fyne.Shortcut) bool {
+	return appShortcuts[shortcut.ShortcutName()]
+}
+
+// typeAppShortcut runs a shortcut of the app while a widget that would handle it is focused
+func typeAppShortcut(shortcut fyne.Shortcut) {
+
+	if windowCanvas, ok := mainWindow.Canvas().(fyne.Shortcutable); ok {
+		windowCanvas.TypedShortcut(shortcut)
+	}
+}
+
+// typedAppKey handles keys that are not typed into a widget: Escape goes back, Page Up and Page Down change result pages
+func typedAppKey(key *fyne.KeyEvent) bool {
+
+	// Keys typed while a dialog is open are meant for the dialog
+	if currentScreen == nil || mainWindow.Canvas().Overlays().Top() != nil {
+		return false
+	}
+
+	switch key.Name {
+	case fyne.KeyEscape:
+		leaveRecipeEntry(goBack)
+
+	case fyne.KeyPageUp:
+		if currentScreen.Kind == "results" {
+			showResultsPage(currentPage - 1)
+		}
+
+	case fyne.KeyPageDown:
+		if currentScreen.Kind == "results" {
+			showResultsPage(currentPage + 1)
+		}
+
+	default:
+		return false
+	}
+
+	return true
+}
+
+// showResultsPage displays another page of the current results
+func showResultsPage(page int) {
+
+	allPages := int(math.Ceil(float64(currentCount) / float64(config.resultsPerPage)))
+
+	if page < 1 || page > allPages {
+		return
+	}
+
+	currentRecipes, currentCount = getCurrentResults(config.resultsPerPage * (page - 1))
+
+	currentPage = page
+	displayResults(allPages, currentResultsTitle)
+}
+
+// focusSearch focuses the search bar, going to the home page if it is not displayed
+func focusSearch() {
+
+	if currentScreen == nil || (currentScreen.Kind != "results" && currentScreen.Kind != "home") {
+		displayHomePage()
+	}
+
+	mainWindow.Canvas().Focus(searchBar)
+}
+
+func editDisplayedRecipe() {
+
+	if currentScreen == nil || currentScreen.Kind != "recipe" {
+		return
+	}
+
+	for _, recipe := range currentRecipes {
+		if recipe.Id == currentScreen.RecipeId {
+			recipeEntry(recipe, "edit")
+			return
+		}
+	}
+}
+
+// FormEntry is an entry that passes app shortcuts on to the window, so they also work while typing
+type FormEntry struct {
+	widget.Entry
+}
+
+func newFormEntry(placeHolder string) *FormEntry {
+
+	entry := &FormEntry{}
+	entry.PlaceHolder = placeHolder
+	entry.ExtendBaseWidget(entry)
+
+	return entry
+}
+
+func (e *FormEntry) TypedShortcut(shortcut fyne.Shortcut) {
+
+	if isAppShortcut(shortcut) {
+		typeAppShortcut(shortcut)
+		return
+	}
+
+	e.Entry.TypedShortcut(shortcut)
+}
+
+// TypedKey leaves the entry on Escape, so that the next Escape goes back
+func (e *FormEntry) TypedKey(key *fyne.KeyEvent) {
+
+	if key.Name == fyne.KeyEscape {
+		mainWindow.Canvas().Unfocus()
+		return
+	}
+
+	e.Entry.TypedKey(key)
+}
+
+// Command is an action that can be run from the command palette
+type Command struct {
+	Name string
+	Run  func()
+}
+
+func paletteCommands() []Command {
+
+	commands := []Command{
+		{Name: tr("New recipe"), Run: func() { leaveRecipeEntry(func() { recipeEntry(Recipe{}, "new") }) }},
+		{Name: tr("Search"), Run: func() { leaveRecipeEntry(focusSearch) }},
+		{Name: tr("All recipes"), Run: func() { leaveRecipeEntry(displayHomePage) }},
+		{Name: tr("Back"), Run: func() { leaveRecipeEntry(goBack) }},
+		{Name: tr("Forward"), Run: func() { leaveRecipeEntry(goForward) }},
+		{Name: tr("Allergens"), Run: showAllergenFilterDialog},
+		{Name: tr("Preferences"), Run: showPreferencesDialog},
+	}
+
+	if currentScreen != nil && currentScreen.Kind == "recipe" {
+		commands = append(commands, Command{Name: tr("Edit recipe"), Run: editDisplayedRecipe})
+	}
+
+	return commands
+}
+
+// fuzzyScore tells if all characters of a query appear in a text in the same order.
+// Lower scores are better matches: characters close together and near the start.
+func fuzzyScore(query string, text string) (int, bool) {
+
+	query = strings.ToLower(foldAccents(strings.TrimSpace(query)))
+	textRunes := []rune(strings.ToLower(foldAccents(text)))
+
+	score := 0
+	position := 0
+
+	for _, queryRune := range query {
+
+		found := false
+
+		for position < len(textRunes) {
+			matched := textRunes[position] == queryRune
+			position++
+
+			if matched {
+				found = true
+				break
+			}
+
+			score++
+		}
+
+		if !found {
+			return 0, false
+		}
+	}
+
+	return score, true
+}
+
+// fuzzyMatchSuggestions returns the candidates that fuzzy match a query, best matches first
+func fuzzyMatchSuggestions(query string, candidates []Suggestion) []Suggestion {
+
+	if len(strings.TrimSpace(query)) == 0 {
+		return []Suggestion{}
+	}
+
+	matches := []Suggestion{}
+	scores := map[Suggestion]int{}
+
+	for _, candidate := range candidates {
+		if score, matched := fuzzyScore(query, candidate.Text); matched {
+			matches = append(matches, candidate)
+			scores[candidate] = score
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool { return scores[matches[i]] < scores[matches[j]] })
+
+	if len(matches) > maxSuggestions {
+		matches = matches[:maxSuggestions]
+	}
+
+	return matches
+}
+
+// showCommandPalette lets the user find an action or a recipe by typing a part of its name
+func showCommandPalette() {
+
+	commands := paletteCommands()
+	recipesByTitle := map[string]Recipe{}
+
+	candidates := []Suggestion{}
+	for _, command := range commands {
+		candidates = append(candidates, Suggestion{Text: command.Name, Kind: "Action"})
+	}
+
+	if localIndex != nil {
+		for _, recipe := range localIndex.recipes {
+			recipesByTitle[recipe.Title] = recipe
+			candidates = append(candidates, Suggestion{Text: recipe.Title, Kind: "Recipe"})
+		}
+	}
+
+	paletteEntry := newSuggestionEntry(func(text string) []Suggestion { return fuzzyMatchSuggestions(text, candidates) })
+	paletteEntry.SetPlaceHolder(tr("Type an action or a recipe..."))
+
+	paletteDialog := dialog.NewCustom(tr("Go to"), tr("Cancel"), paletteEntry, mainWindow)
+
+	run := func(chosen Suggestion) {
+
+		paletteDialog.Hide()
+
+		if chosen.Kind == "Recipe" {
+			leaveRecipeEntry(func() { displayLinkedRecipe(recipesByTitle[chosen.Text]) })
+			return
+		}
+
+		for _, command := range commands {
+			if command.Name == chosen.Text {
+				command.Run()
+				return
+			}
+		}
+	}
+
+	paletteEntry.OnSuggestionChosen = run
+
+	// Enter without choosing runs the best match
+	paletteEntry.OnSubmitted = func(text string) {
+		if matches := fuzzyMatchSuggestions(text, candidates); len(matches) != 0 {
+			run(matches[0])
+		}
+	}
+
+	paletteDialog.Resize(fyne.NewSize(500, paletteDialog.MinSize().Height))
+	paletteDialog.Show()
+	mainWindow.Canvas().Focus(paletteEntry)
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+	"golang.org/x/exp/maps"
+)
+
+// Desktop layout shows the navigation tree, results and recipe details side by side
+
+// Shares of the window width taken by the navigation tree and by results
+const (
+	navigationPaneOffset = 0.2
+	resultsPaneOffset    = 0.4
+)
+
+// Displayed split view, its results pane and detail pane
+var splitView fyne.CanvasObject
+var resultsPane fyne.CanvasObject
+var detailPane *fyne.Container
+
+// Query and page of the results shown in the results pane
+var resultsPaneQuery map[string]string
+var resultsPanePage int
+
+// showSplitView displays results beside the navigation tree, with an empty detail pane
+func showSplitView(results fyne.CanvasObject) {
+
+	resultsPane = results
+	resultsPaneQuery = maps.Clone(currentQuery)
+	resultsPanePage = currentPage
+
+	detailPane = container.NewMax()
+	clearDetailPane()
+
+	contentSplit := container.NewHSplit(resultsPane, detailPane)
+	contentSplit.Offset = resultsPaneOffset
+
+	navigationPane := container.NewBorder(nil, newRecipeButton, nil, nil, navTree)
+
+	mainSplit := container.NewHSplit(navigationPane, contentSplit)
+	mainSplit.Offset = navigationPaneOffset
+
+	splitView = mainSplit
+	mainWindow.SetContent(splitView)
+}
+
+// showsCurrentResults tells if the split view is displayed with results of the current query and page
+func showsCurrentResults() bool {
+	return splitView != nil && mainWindow.Content() == splitView && resultsPanePage == currentPage && maps.Equal(resultsPaneQuery, currentQuery)
+}
+
+// showInDetailPane shows recipe details or entry beside results. Results stay as they are, including their scroll position,
+// unless they are of another query.
+func showInDetailPane(content fyne.CanvasObject, allPages int, searchTerm string) {
+
+	if showsCurrentResults() {
+		// Results may have changed, e.g. after a rating
+		resultsPane.Refresh()
+
+	} else {
+		currentResultsTitle = searchTerm
+		showSplitView(createResultsPane(allPages, searchTerm))
+	}
+
+	detailPane.Objects = []fyne.CanvasObject{content}
+	detailPane.Refresh()
+}
+
+// clearDetailPane leaves only results in the split view, so any of them can be selected again
+func clearDetailPane() {
+
+	if detailPane == nil {
+		return
+	}
+
+	hint := widget.NewLabel(tr("Select a recipe"))
+	detailPane.Objects = []fyne.CanvasObject{container.NewCenter(hint)}
+	detailPane.Refresh()
+
+	if resultsList != nil {
+		resultsList.UnselectAll()
+	}
+}
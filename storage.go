@@ -0,0 +1,89 @@
+package main
+
+import (
+	"encoding/json"
+	"io/ioutil"
+
+	"fyne.io/fyne/v2/storage"
+)
+
+// saveLocalJSON stores a value as a JSON file in the app's private storage
+func saveLocalJSON(fileName string, value interface{}) error {
+
+	fileURI, err := storage.Child(mainApp.Storage().RootURI(), fileName)
+
+	if err != nil {
+		return err
+	}
+
+	writer, err := storage.Writer(fileURI)
+
+	if err != nil {
+		return err
+	}
+
+	defer writer.Close()
+
+	return json.NewEncoder(writer).Encode(value)
+}
+
+// loadLocalJSON reads a JSON file from the app's private storage into value
+func loadLocalJSON(fileName string, value interface{}) error {
+
+	fileURI, err := storage.Child(mainApp.Storage().RootURI(), fileName)
+
+	if err != nil {
+		return err
+	}
+
+	reader, err := storage.Reader(fileURI)
+
+	if err != nil {
+		return err
+	}
+
+	defer reader.Close()
+
+	return json.NewDecoder(reader).Decode(value)
+}
+
+// writeLocalFile stores raw file content in the app's private storage
+func writeLocalFile(fileName string, content []byte) error {
+
+	fileURI, err := storage.Child(mainApp.Storage().RootURI(), fileName)
+
+	if err != nil {
+		return err
+	}
+
+	writer, err := storage.Writer(fileURI)
+
+	if err != nil {
+		return err
+	}
+
+	defer writer.Close()
+
+	_, err = writer.Write(content)
+	return err
+}
+
+// readLocalFile reads raw file content from the app's private storage
+func readLocalFile(fileName string) ([]byte, error) {
+
+	fileURI, err := storage.Child(mainApp.Storage().RootURI(), fileName)
+
+	if err != nil {
+		return []byte{}, err
+	}
+
+	reader, err := storage.Reader(fileURI)
+
+	if err != nil {
+		return []byte{}, err
+	}
+
+	defer reader.Close()
+
+	return ioutil.ReadAll(reader)
+}
@@ -0,0 +1,244 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/data/validation"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+)
+
+// Unit of ingredients that reference another recipe; their quantity is a number of portions of that recipe
+const recipePortionsUnit = "portions"
+
+// Nested recipes are expanded at most this deep, in case a reference cycle was saved before cycle checks
+const maxRecipeDepth = 10
+
+// findRecipe returns a recipe by ID, from the local search index if possible
+func findRecipe(id string) (Recipe, bool) {
+
+	if localIndex != nil {
+		if recipe, found := localIndex.recipe(id); found {
+			return recipe, true
+		}
+	}
+
+	for _, recipe := range getRecipesByIds([]string{id}) {
+		return recipe, true
+	}
+
+	return Recipe{}, false
+}
+
+// recipeFactor returns how many times a referenced recipe is used, e.g. 2 portions of a recipe for 4 give 0.5.
+// A reference without quantity uses the whole recipe.
+func recipeFactor(ingr Ingredient, subRecipe Recipe) float64 {
+
+	if ingr.Quantity == 0 || subRecipe.DefaultPortions <= 0 {
+		return 1
+	}
+
+	return ingr.Quantity / float64(subRecipe.DefaultPortions)
+}
+
+// expandIngredients replaces references to other recipes with their ingredients, recursively,
+// scaled to the number of portions used
+func expandIngredients(ingredientList []Ingredient) []Ingredient {
+	return expandIngredientsToDepth(ingredientList, 1, 0)
+}
+
+func expandIngredientsToDepth(ingredientList []Ingredient, factor float64, depth int) []Ingredient {
+
+	expanded := []Ingredient{}
+
+	for _, ingr := range ingredientList {
+
+		if len(ingr.RecipeId) == 0 {
+			ingr.Quantity *= factor
+			expanded = append(expanded, ingr)
+			continue
+		}
+
+		subRecipe, found := findRecipe(ingr.RecipeId)
+
+		if !found || depth >= maxRecipeDepth {
+			continue
+		}
+
+		subFactor := factor * recipeFactor(ingr, subRecipe)
+		expanded = append(expanded, expandIngredientsToDepth(subRecipe.Ingredients, subFactor, depth+1)...)
+	}
+
+	return expanded
+}
+
+// expandedRecipe returns the recipe with ingredients of referenced recipes in place of the references
+func expandedRecipe(recipe Recipe) Recipe {
+
+	recipe.Ingredients = expandIngredients(recipe.Ingredients)
+	return recipe
+}
+
+// findReferenceCycle checks if the ingredients of a recipe reference the recipe itself, directly or through
+// other recipes, and returns titles of the recipes in the cycle
+func findReferenceCycle(recipeId string, ingredientList []Ingredient) ([]string, bool) {
+
+	if len(recipeId) == 0 {
+		return []string{}, false
+	}
+
+	visited := map[string]bool{}
+
+	var visit func(ingredientList []Ingredient, path []string) ([]string, bool)
+
+	visit = func(ingredientList []Ingredient, path []string) ([]string, bool) {
+
+		for _, ingr := range ingredientList {
+
+			if len(ingr.RecipeId) == 0 {
+				continue
+			}
+
+			if ingr.RecipeId == recipeId {
+				return append(path, ingr.Name), true
+			}
+
+			if visited[ingr.RecipeId] {
+				continue
+			}
+			visited[ingr.RecipeId] = true
+
+			subRecipe, found := findRecipe(ingr.RecipeId)
+			if !found {
+				continue
+			}
+
+			if cycle, found := visit(subRecipe.Ingredients, append(path, subRecipe.Title)); found {
+				return cycle, true
+			}
+		}
+
+		return path, false
+	}
+
+	return visit(ingredientList, []string{})
+}
+
+// createRecipeReference shows an ingredient that references another recipe as a link to it,
+// with the ingredients of the referenced recipe listed below
+func createRecipeReference(ingr Ingredient, position int) fyne.CanvasObject {
+
+	subRecipe, found := findRecipe(ingr.RecipeId)
+
+	if !found {
+		return widget.NewLabel(fmt.Sprint(position) + ". " + ingredientText(ingr) + tr(" (recipe not found)"))
+	}
+
+	// The referenced recipe may have been renamed since the reference was saved
+	ingr.Name = subRecipe.Title
+
+	recipeLink := widget.NewHyperlink(fmt.Sprint(position)+". "+ingredientText(ingr), nil)
+	recipeLink.OnTapped = func() { displayLinkedRecipe(subRecipe) }
+
+	subIngredients := container.NewVBox()
+	for _, subIngr := range expandIngredients(scaledIngredients(subRecipe.Ingredients, recipeFactor(ingr, subRecipe))) {
+
+		subIngr.Quantity = roundQuantity(subIngr.Quantity)
+
+		subLabel := widget.NewLabel("- " + ingredientText(subIngr))
+		subLabel.Wrapping = fyne.TextWrapWord
+		subIngredients.Add(subLabel)
+	}
+
+	return container.NewVBox(recipeLink, container.NewBorder(nil, nil, widget.NewLabel("    "), nil, subIngredients))
+}
+
+// displayLinkedRecipe displays details of a recipe that is referenced in an ingredient list
+func displayLinkedRecipe(recipe Recipe) {
+
+	startQuery("recipe")
+	currentQuery["recipeId"] = recipe.Id
+
+	currentRecipes, currentCount = getCurrentResults(0)
+
+	if len(currentRecipes) == 0 {
+		return
+	}
+
+	allPages := int(math.Ceil(float64(currentCount) / float64(config.resultsPerPage)))
+	currentPage = 1
+	displayRecipeDetails(0, allPages, recipe.Title)
+}
+
+// scaledIngredients multiplies ingredient quantities by a factor. References to other recipes are scaled
+// through the number of their portions, which is their quantity.
+func scaledIngredients(ingredientList []Ingredient, factor float64) []Ingredient {
+
+	scaled := []Ingredient{}
+
+	for _, ingr := range ingredientList {
+		ingr.Quantity *= factor
+		scaled = append(scaled, ingr)
+	}
+
+	return scaled
+}
+
+// showRecipeReferenceDialog lets the user choose a recipe and the number of its portions to use as an ingredient
+func showRecipeReferenceDialog(currentRecipeId string, onChosen func(Ingredient)) {
+
+	recipesByTitle := map[string]Recipe{}
+	if localIndex != nil {
+		for _, recipe := range localIndex.recipes {
+			if recipe.Id != currentRecipeId {
+				recipesByTitle[strings.ToLower(recipe.Title)] = recipe
+			}
+		}
+	}
+
+	titleEntry := newSuggestionEntry(func(text string) []Suggestion {
+
+		candidates := []Suggestion{}
+		for _, recipe := range recipesByTitle {
+			candidates = append(candidates, Suggestion{Text: recipe.Title, Kind: "Recipe"})
+		}
+
+		return matchSuggestions(text, candidates)
+	})
+	titleEntry.SetPlaceHolder(tr("Recipe title"))
+
+	portionEntry := &widget.Entry{PlaceHolder: tr("Portions (empty for whole recipe)")}
+	portionEntry.Validator = validation.NewRegexp(`^\d*([.,]\d{0,3})?$`, tr("Value has to be a number."))
+
+	formItems := []*widget.FormItem{
+		widget.NewFormItem(tr("Recipe"), titleEntry),
+		widget.NewFormItem(tr("Portions"), portionEntry),
+	}
+
+	dialog.ShowForm(tr("Add recipe as ingredient"), tr("Add"), tr("Cancel"), formItems, func(confirmed bool) {
+
+		if !confirmed {
+			return
+		}
+
+		subRecipe, exists := recipesByTitle[strings.ToLower(strings.TrimSpace(titleEntry.Text))]
+
+		if !exists {
+			dialog.NewInformation(tr("Error"), fmt.Sprintf(tr("Recipe %s does not exist."), titleEntry.Text), mainWindow).Show()
+			return
+		}
+
+		// Without portions the whole recipe is used
+		portions, err := parseNumber(portionEntry.Text)
+		if err != nil || portions == 0 {
+			portions = float64(subRecipe.DefaultPortions)
+		}
+
+		onChosen(Ingredient{Name: subRecipe.Title, Quantity: portions, Unit: recipePortionsUnit, RecipeId: subRecipe.Id})
+
+	}, mainWindow)
+}
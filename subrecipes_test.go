@@ -0,0 +1,76 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+// withTestIndex makes recipes findable without a database while a test runs
+func withTestIndex(t *testing.T, recipes []Recipe) {
+
+	previous := localIndex
+	localIndex = newSearchIndex(recipes)
+
+	t.Cleanup(func() { localIndex = previous })
+}
+
+func nestedRecipes() []Recipe {
+	return []Recipe{
+		{Id: "soup", Title: "Soup", DefaultPortions: 4, Ingredients: []Ingredient{
+			{Name: "Stock", Quantity: 2, Unit: recipePortionsUnit, RecipeId: "stock"},
+			{Name: "carrot", Quantity: 2},
+		}},
+		{Id: "stock", Title: "Stock", DefaultPortions: 4, Ingredients: []Ingredient{
+			{Name: "water", Quantity: 2, Unit: "l"},
+			{Name: "Spice mix", RecipeId: "spices"},
+		}},
+		{Id: "spices", Title: "Spice mix", DefaultPortions: 1, Ingredients: []Ingredient{
+			{Name: "salt", Quantity: 10, Unit: "g"},
+		}},
+	}
+}
+
+func TestFindReferenceCycle(t *testing.T) {
+
+	withTestIndex(t, nestedRecipes())
+
+	tests := []struct {
+		name        string
+		recipeId    string
+		ingredients []Ingredient
+		cycle       []string
+		found       bool
+	}{
+		{"new recipe", "", []Ingredient{{Name: "Soup", RecipeId: "soup"}}, []string{}, false},
+		{"no references", "spices", []Ingredient{{Name: "pepper"}}, []string{}, false},
+		{"direct", "spices", []Ingredient{{Name: "Spice mix", RecipeId: "spices"}}, []string{"Spice mix"}, true},
+		{"through other recipes", "spices", []Ingredient{{Name: "Soup", RecipeId: "soup"}}, []string{"Soup", "Stock", "Spice mix"}, true},
+		{"other recipe", "soup", []Ingredient{{Name: "Spice mix", RecipeId: "spices"}}, []string{"Spice mix"}, false},
+	}
+
+	for _, test := range tests {
+
+		cycle, found := findReferenceCycle(test.recipeId, test.ingredients)
+
+		if found != test.found || (found && !reflect.DeepEqual(cycle, test.cycle)) {
+			t.Errorf("findReferenceCycle(%s) = %v, %v, want %v, %v", test.name, cycle, found, test.cycle, test.found)
+		}
+	}
+}
+
+func TestExpandIngredients(t *testing.T) {
+
+	recipes := nestedRecipes()
+	withTestIndex(t, recipes)
+
+	// Half of the stock with all of its spice mix
+	want := []Ingredient{
+		{Name: "water", Quantity: 1, Unit: "l"},
+		{Name: "salt", Quantity: 5, Unit: "g"},
+		{Name: "carrot", Quantity: 2},
+	}
+
+	if expanded := expandIngredients(recipes[0].Ingredients); !reflect.DeepEqual(expanded, want) {
+		t.Errorf("expandIngredients() = %v, want %v", expanded, want)
+	}
+}
@@ -0,0 +1,302 @@
+package main
+
+import (
+	"strconv"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/widget"
+)
+
+// SubstituteItem is one ingredient of a substitute. Quantity is the original quantity multiplied by Ratio,
+// in the original unit when Unit is empty or in Unit otherwise (e.g. 1 tbsp of flaxseed per egg).
+type SubstituteItem struct {
+	Name  string
+	Ratio float64
+	Unit  string
+}
+
+type Substitute struct {
+	Items []SubstituteItem
+	Notes string
+}
+
+// Substitution lists substitutes of an ingredient. Exceptions are ingredient names that contain the name
+// but are something else, e.g. "peanut butter" is not butter.
+type Substitution struct {
+	Ingredient  string
+	Exceptions  []string
+	Substitutes []Substitute
+}
+
+// Substitutes for ingredients that are often missing at home
+var substitutions = []Substitution{
+	{Ingredient: "buttermilk", Substitutes: []Substitute{
+		{Items: []SubstituteItem{{Name: "milk", Ratio: 0.94}, {Name: "lemon juice", Ratio: 0.06}}, Notes: "Let stand for 5 minutes before using."},
+		{Items: []SubstituteItem{{Name: "plain yogurt", Ratio: 0.75}, {Name: "milk", Ratio: 0.25}}},
+	}},
+	{Ingredient: "sour cream", Substitutes: []Substitute{
+		{Items: []SubstituteItem{{Name: "plain yogurt", Ratio: 1}}},
+		{Items: []SubstituteItem{{Name: "cream cheese", Ratio: 0.75}, {Name: "milk", Ratio: 0.25}}},
+	}},
+	{Ingredient: "heavy cream", Substitutes: []Substitute{
+		{Items: []SubstituteItem{{Name: "milk", Ratio: 0.75}, {Name: "melted butter", Ratio: 0.25}}, Notes: "Not suitable for whipping."},
+	}},
+	{Ingredient: "butter", Exceptions: []string{"peanut butter", "almond butter", "cocoa butter", "nut butter"}, Substitutes: []Substitute{
+		{Items: []SubstituteItem{{Name: "vegetable oil", Ratio: 0.8}}},
+		{Items: []SubstituteItem{{Name: "margarine", Ratio: 1}}},
+	}},
+	{Ingredient: "milk", Exceptions: []string{"coconut milk", "condensed milk", "evaporated milk", "almond milk", "soy milk", "oat milk"}, Substitutes: []Substitute{
+		{Items: []SubstituteItem{{Name: "soy milk", Ratio: 1}}},
+		{Items: []SubstituteItem{{Name: "evaporated milk", Ratio: 0.5}, {Name: "water", Ratio: 0.5}}},
+	}},
+	{Ingredient: "egg", Exceptions: []string{"egg white", "egg yolk"}, Substitutes: []Substitute{
+		{Items: []SubstituteItem{{Name: "ground flaxseed", Ratio: 1, Unit: "tbsp"}, {Name: "water", Ratio: 3, Unit: "tbsp"}}, Notes: "For baking. Let stand for 5 minutes before using."},
+		{Items: []SubstituteItem{{Name: "mashed banana", Ratio: 60, Unit: "g"}}, Notes: "For sweet baking."},
+	}},
+	{Ingredient: "self-raising flour", Substitutes: []Substitute{
+		{Items: []SubstituteItem{{Name: "flour", Ratio: 1}, {Name: "baking powder", Ratio: 0.05}}},
+	}},
+	{Ingredient: "cornstarch", Substitutes: []Substitute{
+		{Items: []SubstituteItem{{Name: "flour", Ratio: 2}}},
+	}},
+	{Ingredient: "baking powder", Substitutes: []Substitute{
+		{Items: []SubstituteItem{{Name: "baking soda", Ratio: 0.25}, {Name: "lemon juice", Ratio: 0.5}}},
+	}},
+	{Ingredient: "fresh yeast", Substitutes: []Substitute{
+		{Items: []SubstituteItem{{Name: "dry yeast", Ratio: 0.33}}},
+	}},
+	{Ingredient: "dry yeast", Substitutes: []Substitute{
+		{Items: []SubstituteItem{{Name: "fresh yeast", Ratio: 3}}},
+	}},
+	{Ingredient: "brown sugar", Substitutes: []Substitute{
+		{Items: []SubstituteItem{{Name: "sugar", Ratio: 1}}},
+	}},
+	{Ingredient: "honey", Substitutes: []Substitute{
+		{Items: []SubstituteItem{{Name: "maple syrup", Ratio: 1}}},
+		{Items: []SubstituteItem{{Name: "sugar", Ratio: 1.25}}, Notes: "Add a little more liquid."},
+	}},
+	{Ingredient: "lemon juice", Substitutes: []Substitute{
+		{Items: []SubstituteItem{{Name: "lime juice", Ratio: 1}}},
+		{Items: []SubstituteItem{{Name: "vinegar", Ratio: 0.5}}},
+	}},
+	{Ingredient: "white wine", Substitutes: []Substitute{
+		{Items: []SubstituteItem{{Name: "vegetable stock", Ratio: 0.9}, {Name: "vinegar", Ratio: 0.1}}},
+	}},
+	{Ingredient: "red wine", Substitutes: []Substitute{
+		{Items: []SubstituteItem{{Name: "beef stock", Ratio: 0.9}, {Name: "balsamic vinegar", Ratio: 0.1}}},
+	}},
+	{Ingredient: "breadcrumbs", Substitutes: []Substitute{
+		{Items: []SubstituteItem{{Name: "rolled oats", Ratio: 1}}},
+	}},
+	{Ingredient: "parmesan", Substitutes: []Substitute{
+		{Items: []SubstituteItem{{Name: "pecorino", Ratio: 1}}},
+	}},
+	{Ingredient: "mascarpone", Substitutes: []Substitute{
+		{Items: []SubstituteItem{{Name: "cream cheese", Ratio: 1}}},
+	}},
+	{Ingredient: "ricotta", Substitutes: []Substitute{
+		{Items: []SubstituteItem{{Name: "cottage cheese", Ratio: 1}}},
+	}},
+	{Ingredient: "shallot", Substitutes: []Substitute{
+		{Items: []SubstituteItem{{Name: "onion", Ratio: 1}}},
+	}},
+	{Ingredient: "garlic", Exceptions: []string{"garlic powder"}, Substitutes: []Substitute{
+		{Items: []SubstituteItem{{Name: "garlic powder", Ratio: 0.125, Unit: "tsp"}}, Notes: "Per clove of garlic."},
+	}},
+	{Ingredient: "tomato paste", Substitutes: []Substitute{
+		{Items: []SubstituteItem{{Name: "tomato passata", Ratio: 3}}, Notes: "Cook a little longer to reduce."},
+	}},
+}
+
+// Substitutes chosen by the user: recipe ID -> ingredient name -> substitute position.
+// Names stay valid when ingredients of a saved recipe are reordered, added or removed.
+var appliedSubstitutions = map[string]map[string]int{}
+
+// findSubstitution returns substitutes of an ingredient.
+// Like findFood, it ignores plurals and synonyms and prefers the longest ingredient name contained in the name.
+func findSubstitution(ingredientName string) (Substitution, bool) {
+
+	var bestSubstitution Substitution
+	bestLength := 0
+
+	name := normalizeFoodName(ingredientName)
+
+	for _, variant := range ingredientSynonyms(name) {
+
+		variantTerms := tokenize(variant)
+
+		for _, substitution := range substitutions {
+
+			substitutionTerms := tokenize(substitution.Ingredient)
+
+			if len(substitutionTerms) == 0 || len(substitutionTerms) <= bestLength || !containsAllTerms(variantTerms, substitutionTerms) {
+				continue
+			}
+
+			excepted := false
+			for _, exception := range substitution.Exceptions {
+				if strings.Contains(variant, exception) {
+					excepted = true
+					break
+				}
+			}
+
+			if excepted {
+				continue
+			}
+
+			bestSubstitution = substitution
+			bestLength = len(substitutionTerms)
+		}
+	}
+
+	return bestSubstitution, bestLength > 0
+}
+
+// substituteIngredients returns the ingredients that replace an ingredient, with quantities rescaled by the substitute's ratios
+func substituteIngredients(ingr Ingredient, substitute Substitute) []Ingredient {
+
+	replacement := []Ingredient{}
+
+	for _, item := range substitute.Items {
+
+		unit := item.Unit
+		if len(unit) == 0 {
+			unit = ingr.Unit
+		}
+
+		replacement = append(replacement, Ingredient{
+			Name:     item.Name,
+			Quantity: ingr.Quantity * item.Ratio,
+			Unit:     unit,
+			Notes:    tr("instead of ") + ingr.Name,
+			Group:    ingr.Group,
+		})
+	}
+
+	return replacement
+}
+
+// withSubstitutions returns the recipe with substitutes chosen by the user in place of the original ingredients
+func withSubstitutions(recipe Recipe) Recipe {
+
+	chosen := appliedSubstitutions[recipe.Id]
+
+	if len(chosen) == 0 {
+		return recipe
+	}
+
+	ingredientList := []Ingredient{}
+
+	for _, ingr := range recipe.Ingredients {
+
+		substituteIndex, exists := chosen[ingr.Name]
+		substitution, found := findSubstitution(ingr.Name)
+
+		if !exists || !found || substituteIndex >= len(substitution.Substitutes) {
+			ingredientList = append(ingredientList, ingr)
+			continue
+		}
+
+		ingredientList = append(ingredientList, substituteIngredients(ingr, substitution.Substitutes[substituteIndex])...)
+	}
+
+	recipe.Ingredients = ingredientList
+	return recipe
+}
+
+// substituteText describes a substitute for the given ingredient amount, e.g. "240 ml milk + 15 ml lemon juice"
+func substituteText(ingr Ingredient, substitute Substitute) string {
+
+	parts := []string{}
+
+	for _, replacement := range substituteIngredients(ingr, substitute) {
+
+		part := replacement.Name
+		if replacement.Quantity != 0 {
+			part = formatNumber(roundQuantity(replacement.Quantity), -1) + " " + replacement.Unit + " " + replacement.Name
+		}
+
+		parts = append(parts, strings.Join(strings.Fields(part), " "))
+	}
+
+	return strings.Join(parts, " + ")
+}
+
+// roundQuantity rounds rescaled quantities to two decimals, so they stay readable
+func roundQuantity(quantity float64) float64 {
+
+	rounded, _ := strconv.ParseFloat(strconv.FormatFloat(quantity, 'f', 2, 64), 64)
+	return rounded
+}
+
+// showSubstitutionDialog lists substitutes of an ingredient of a recipe.
+// The dialog is shown over parent. onChanged is called when the user applies a substitute or shows the original ingredient again.
+func showSubstitutionDialog(recipe Recipe, ingredientIndex int, parent fyne.Window, onChanged func()) {
+
+	ingr := recipe.Ingredients[ingredientIndex]
+
+	substitution, found := findSubstitution(ingr.Name)
+
+	if !found {
+		return
+	}
+
+	var substitutionDialog dialog.Dialog
+
+	options := container.NewVBox()
+
+	for j, substitute := range substitution.Substitutes {
+
+		substituteIndex := j
+
+		optionLabel := widget.NewLabel(substituteText(ingr, substitute))
+		optionLabel.Wrapping = fyne.TextWrapWord
+
+		applyButton := widget.NewButton(tr("Use in recipe"), func() {
+
+			if _, exists := appliedSubstitutions[recipe.Id]; !exists {
+				appliedSubstitutions[recipe.Id] = map[string]int{}
+			}
+
+			appliedSubstitutions[recipe.Id][ingr.Name] = substituteIndex
+
+			substitutionDialog.Hide()
+			onChanged()
+		})
+
+		options.Add(container.NewBorder(nil, nil, nil, applyButton, optionLabel))
+
+		if len(substitute.Notes) != 0 {
+			notesLabel := widget.NewLabel(substitute.Notes)
+			notesLabel.TextStyle = fyne.TextStyle{Italic: true}
+			notesLabel.Wrapping = fyne.TextWrapWord
+			options.Add(notesLabel)
+		}
+
+		options.Add(widget.NewSeparator())
+	}
+
+	if _, applied := appliedSubstitutions[recipe.Id][ingr.Name]; applied {
+
+		originalButton := widget.NewButton(tr("Use original ingredient"), func() {
+
+			delete(appliedSubstitutions[recipe.Id], ingr.Name)
+
+			substitutionDialog.Hide()
+			onChanged()
+		})
+
+		options.Add(container.NewHBox(layout.NewSpacer(), originalButton))
+	}
+
+	optionsScroll := container.NewVScroll(options)
+	optionsScroll.SetMinSize(fyne.NewSize(400, 200))
+
+	substitutionDialog = dialog.NewCustom(tr("Substitutes for ")+ingr.Name, tr("Close"), optionsScroll, parent)
+	substitutionDialog.Show()
+}
@@ -0,0 +1,60 @@
+package main
+
+import "testing"
+
+func TestFindSubstitution(t *testing.T) {
+
+	tests := []struct {
+		name       string
+		ingredient string
+		found      bool
+	}{
+		{"Eggs", "egg", true},
+		{"unsalted butter", "butter", true},
+		{"buttermilk", "buttermilk", true},
+		{"sour cream", "sour cream", true},
+		{"peanut butter", "", false},
+		{"egg whites", "", false},
+		{"coconut milk", "", false},
+		{"carrot", "", false},
+	}
+
+	for _, test := range tests {
+
+		substitution, found := findSubstitution(test.name)
+
+		if found != test.found || substitution.Ingredient != test.ingredient {
+			t.Errorf("findSubstitution(%q) = %q, %v, want %q, %v", test.name, substitution.Ingredient, found, test.ingredient, test.found)
+		}
+	}
+}
+
+func TestWithSubstitutions(t *testing.T) {
+
+	defer func() { delete(appliedSubstitutions, "test") }()
+
+	recipe := Recipe{Id: "test", Ingredients: []Ingredient{{Name: "flour", Quantity: 200, Unit: "g"}, {Name: "butter", Quantity: 100, Unit: "g"}}}
+
+	butter, _ := findSubstitution("butter")
+	appliedSubstitutions["test"] = map[string]int{"butter": 0}
+
+	// The substitute stays with its ingredient after the ingredients were reordered
+	recipe.Ingredients[0], recipe.Ingredients[1] = recipe.Ingredients[1], recipe.Ingredients[0]
+
+	substituted := withSubstitutions(recipe)
+	replacement := substituteIngredients(recipe.Ingredients[0], butter.Substitutes[0])
+
+	if len(substituted.Ingredients) != len(replacement)+1 {
+		t.Fatalf("withSubstitutions() has %d ingredients, want %d", len(substituted.Ingredients), len(replacement)+1)
+	}
+
+	for j, ingr := range replacement {
+		if substituted.Ingredients[j].Name != ingr.Name || substituted.Ingredients[j].Quantity != ingr.Quantity {
+			t.Errorf("ingredient %d = %v, want %v", j, substituted.Ingredients[j], ingr)
+		}
+	}
+
+	if last := substituted.Ingredients[len(replacement)]; last.Name != "flour" {
+		t.Errorf("last ingredient = %q, want flour", last.Name)
+	}
+}
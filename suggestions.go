@@ -0,0 +1,355 @@
+package main
+
+import (
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+// Time to wait after the last keystroke before suggestions are updated
+const suggestionDelay = 300 * time.Millisecond
+
+const maxSuggestions = 8
+
+type Suggestion struct {
+	Text string
+	Kind string
+}
+
+// SuggestionEntry is an entry that shows matching suggestions in a popup while the user types
+type SuggestionEntry struct {
+	widget.Entry
+
+	OnSuggestionChosen func(suggestion Suggestion)
+
+	suggest     func(text string) []Suggestion
+	suggestions []Suggestion
+	selected    int
+	list        *widget.List
+	keys        *suggestionKeys
+	popUp       *widget.PopUp
+	debounce    *time.Timer
+}
+
+// suggestionKeys passes keyboard input on to the entry while suggestions are shown.
+// Fyne sends keys to the topmost popup, while the entry stays focused in the window below it.
+type suggestionKeys struct {
+	widget.BaseWidget
+	entry *SuggestionEntry
+}
+
+func newSuggestionEntry(suggest func(text string) []Suggestion) *SuggestionEntry {
+
+	entry := &SuggestionEntry{suggest: suggest, selected: -1}
+	entry.ExtendBaseWidget(entry)
+
+	entry.keys = &suggestionKeys{entry: entry}
+	entry.keys.ExtendBaseWidget(entry.keys)
+
+	entry.list = widget.NewList(
+		func() int {
+			return len(entry.suggestions)
+		},
+		func() fyne.CanvasObject {
+			return container.NewHBox(widget.NewLabel("suggestion"), layout.NewSpacer(), widget.NewLabel("kind"))
+		},
+		func(i widget.ListItemID, o fyne.CanvasObject) {
+			textLabel := o.(*fyne.Container).Objects[0].(*widget.Label)
+			kindLabel := o.(*fyne.Container).Objects[2].(*widget.Label)
+
+			textLabel.TextStyle = fyne.TextStyle{Bold: i == entry.selected}
+			textLabel.SetText(entry.suggestions[i].Text)
+			kindLabel.TextStyle = fyne.TextStyle{Italic: true}
+			kindLabel.SetText(tr(entry.suggestions[i].Kind))
+		})
+
+	entry.list.OnSelected = func(id widget.ListItemID) {
+		entry.list.UnselectAll()
+		entry.chooseSuggestion(id)
+	}
+
+	entry.OnChanged = func(text string) {
+
+		if entry.debounce != nil {
+			entry.debounce.Stop()
+		}
+
+		if len(strings.TrimSpace(text)) == 0 {
+			entry.hideSuggestions()
+			return
+		}
+
+		// Suggestions for the typed text are shown on the UI thread, unless the user typed on in the meantime
+		entry.debounce = time.AfterFunc(suggestionDelay, func() {
+			entryCanvas := fyne.CurrentApp().Driver().CanvasForObject(entry)
+
+			// Entry is not displayed anymore
+			if entryCanvas == nil {
+				return
+			}
+
+			runOnUIThread(windowForCanvas(entryCanvas), func() {
+				if entry.Text == text {
+					entry.showSuggestions(text)
+				}
+			})
+		})
+	}
+
+	return entry
+}
+
+// TypedKey moves through suggestions with the arrow keys and chooses one with Enter, other keys are handled by the entry
+func (e *SuggestionEntry) TypedKey(key *fyne.KeyEvent) {
+
+	shown := e.popUp != nil && e.popUp.Visible()
+
+	switch key.Name {
+	case fyne.KeyDown:
+		if !shown && len(strings.TrimSpace(e.Text)) != 0 {
+			e.showSuggestions(e.Text)
+		}
+
+		e.moveSelection(1)
+		return
+
+	case fyne.KeyUp:
+		if shown {
+			e.moveSelection(-1)
+			return
+		}
+
+	case fyne.KeyReturn, fyne.KeyEnter:
+		if shown && e.selected >= 0 {
+			e.chooseSuggestion(e.selected)
+			return
+		}
+
+		e.hideSuggestions()
+
+	case fyne.KeyEscape:
+		if shown {
+			e.hideSuggestions()
+			return
+		}
+	}
+
+	e.Entry.TypedKey(key)
+}
+
+// TypedShortcut lets app shortcuts work while the user types in the entry
+func (e *SuggestionEntry) TypedShortcut(shortcut fyne.Shortcut) {
+
+	if isAppShortcut(shortcut) {
+		typeAppShortcut(shortcut)
+		return
+	}
+
+	e.Entry.TypedShortcut(shortcut)
+}
+
+func (e *SuggestionEntry) showSuggestions(text string) {
+
+	e.suggestions = e.suggest(text)
+	e.selected = -1
+
+	if len(e.suggestions) == 0 {
+		e.hideSuggestions()
+		return
+	}
+
+	driver := fyne.CurrentApp().Driver()
+	entryCanvas := driver.CanvasForObject(e)
+
+	// Entry is not displayed anymore
+	if entryCanvas == nil {
+		return
+	}
+
+	if e.popUp == nil || e.popUp.Canvas != entryCanvas {
+		e.popUp = widget.NewPopUp(container.NewMax(e.list, e.keys), entryCanvas)
+	}
+
+	opening := !e.popUp.Visible()
+
+	rowHeight := widget.NewLabel("").MinSize().Height + theme.SeparatorThicknessSize()
+	e.popUp.Resize(fyne.NewSize(e.Size().Width, rowHeight*float32(len(e.suggestions))))
+	e.popUp.ShowAtPosition(driver.AbsolutePositionForObject(e).Add(fyne.NewPos(0, e.Size().Height)))
+
+	e.list.Refresh()
+	e.list.ScrollToTop()
+
+	// Keys typed while the popup is open reach the entry through the popup
+	if opening {
+		entryCanvas.Focus(e.keys)
+	}
+}
+
+func (e *SuggestionEntry) hideSuggestions() {
+
+	if e.debounce != nil {
+		e.debounce.Stop()
+	}
+
+	if e.popUp == nil || !e.popUp.Visible() {
+		return
+	}
+
+	e.popUp.Hide()
+	e.selected = -1
+}
+
+func (e *SuggestionEntry) moveSelection(step int) {
+
+	if len(e.suggestions) == 0 {
+		return
+	}
+
+	e.selected += step
+
+	if e.selected < 0 {
+		e.selected = len(e.suggestions) - 1
+
+	} else if e.selected >= len(e.suggestions) {
+		e.selected = 0
+	}
+
+	e.list.Refresh()
+	e.list.ScrollTo(e.selected)
+}
+
+func (e *SuggestionEntry) chooseSuggestion(id int) {
+
+	if id < 0 || id >= len(e.suggestions) {
+		return
+	}
+
+	chosen := e.suggestions[id]
+	e.hideSuggestions()
+
+	if e.OnSuggestionChosen != nil {
+		e.OnSuggestionChosen(chosen)
+
+	} else {
+		e.SetText(chosen.Text)
+		e.CursorColumn = len([]rune(chosen.Text))
+
+		// Setting the text should not open the suggestions again
+		if e.debounce != nil {
+			e.debounce.Stop()
+		}
+	}
+}
+
+func (k *suggestionKeys) CreateRenderer() fyne.WidgetRenderer {
+	return widget.NewSimpleRenderer(container.NewWithoutLayout())
+}
+
+func (k *suggestionKeys) FocusGained() {}
+
+func (k *suggestionKeys) FocusLost() {}
+
+func (k *suggestionKeys) TypedRune(r rune) {
+	k.entry.TypedRune(r)
+}
+
+func (k *suggestionKeys) TypedKey(key *fyne.KeyEvent) {
+	k.entry.TypedKey(key)
+}
+
+func (k *suggestionKeys) TypedShortcut(shortcut fyne.Shortcut) {
+	k.entry.TypedShortcut(shortcut)
+}
+
+// KeyDown and KeyUp let the entry select text with Shift while suggestions are shown
+func (k *suggestionKeys) KeyDown(key *fyne.KeyEvent) {
+	k.entry.KeyDown(key)
+}
+
+func (k *suggestionKeys) KeyUp(key *fyne.KeyEvent) {
+	k.entry.KeyUp(key)
+}
+
+// searchSuggestions returns recipe titles, categories, countries, ingredients and tags that match the typed text
+func searchSuggestions(text string) []Suggestion {
+
+	candidates := []Suggestion{}
+
+	if localIndex != nil {
+		for _, recipe := range localIndex.recipes {
+			candidates = append(candidates, Suggestion{Text: recipe.Title, Kind: "Recipe"})
+		}
+	}
+
+	for _, category := range categories {
+		candidates = append(candidates, Suggestion{Text: category, Kind: "Category"})
+	}
+
+	for _, country := range countries {
+		candidates = append(candidates, Suggestion{Text: country, Kind: "Country"})
+	}
+
+	for _, ingredient := range ingredients {
+		candidates = append(candidates, Suggestion{Text: ingredient, Kind: "Main ingredient"})
+	}
+
+	for _, tag := range tags {
+		candidates = append(candidates, Suggestion{Text: tag.Tag, Kind: "Tag"})
+	}
+
+	if localIndex != nil {
+		for _, recipe := range localIndex.recipes {
+			for _, ingr := range recipe.Ingredients {
+				candidates = append(candidates, Suggestion{Text: ingr.Name, Kind: "Ingredient"})
+			}
+		}
+	}
+
+	return matchSuggestions(text, candidates)
+}
+
+// matchSuggestions filters candidates by typed text, ignoring case and accents.
+// Candidates that start with the text come first, followed by those that contain it.
+func matchSuggestions(text string, candidates []Suggestion) []Suggestion {
+
+	query := strings.ToLower(foldAccents(strings.TrimSpace(text)))
+
+	if len(query) == 0 {
+		return []Suggestion{}
+	}
+
+	prefixMatches := []Suggestion{}
+	otherMatches := []Suggestion{}
+	seen := map[string]bool{}
+
+	for _, candidate := range candidates {
+
+		folded := strings.ToLower(foldAccents(strings.TrimSpace(candidate.Text)))
+
+		if len(folded) == 0 || seen[folded] {
+			continue
+		}
+
+		if strings.HasPrefix(folded, query) {
+			prefixMatches = append(prefixMatches, candidate)
+			seen[folded] = true
+
+		} else if strings.Contains(folded, query) {
+			otherMatches = append(otherMatches, candidate)
+			seen[folded] = true
+		}
+	}
+
+	matches := append(prefixMatches, otherMatches...)
+
+	if len(matches) > maxSuggestions {
+		matches = matches[:maxSuggestions]
+	}
+
+	return matches
+}
@@ -0,0 +1,49 @@
+package main
+
+import "strings"
+
+// Groups of ingredient names that mean the same thing
+var synonymGroups = [][]string{
+	{"cilantro", "coriander"},
+	{"aubergine", "eggplant"},
+	{"courgette", "zucchini"},
+	{"scallion", "spring onion", "green onion"},
+	{"chickpea", "garbanzo"},
+	{"bell pepper", "capsicum", "sweet pepper"},
+	{"rocket", "arugula"},
+	{"prawn", "shrimp"},
+	{"cornstarch", "cornflour", "corn starch"},
+	{"icing sugar", "powdered sugar", "confectioners sugar"},
+	{"beetroot", "beet"},
+	{"swede", "rutabaga"},
+	{"minced meat", "ground meat"},
+	{"double cream", "heavy cream"},
+	{"baking soda", "bicarbonate of soda"},
+	{"tahini", "tahina"},
+}
+
+// ingredientSynonyms returns the ingredient name together with its variants using known synonyms,
+// e.g. "fresh cilantro" also gives "fresh coriander"
+func ingredientSynonyms(name string) []string {
+
+	name = strings.ToLower(strings.TrimSpace(name))
+	names := []string{name}
+
+	for _, group := range synonymGroups {
+		for _, synonym := range group {
+
+			if !strings.Contains(name, synonym) {
+				continue
+			}
+
+			for _, other := range group {
+				if other != synonym {
+					names = append(names, strings.Replace(name, synonym, other, 1))
+				}
+			}
+			break
+		}
+	}
+
+	return names
+}
@@ -0,0 +1,244 @@
+package main
+
+import (
+	"math"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	"golang.org/x/exp/slices"
+)
+
+// Prefix of navigation tree node IDs in the "By tag" branch
+const tagNodePrefix = "tag:"
+
+// Separator of tags stored in currentQuery
+const tagQuerySeparator = ","
+
+type TagCount struct {
+	Tag   string `json:"_id"`
+	Count int    `json:"count"`
+}
+
+// normalizeTag makes tags case insensitive and removes characters used for storing tag queries
+func normalizeTag(tag string) string {
+	return strings.ToLower(strings.TrimSpace(strings.ReplaceAll(tag, tagQuerySeparator, " ")))
+}
+
+// tagNames returns tag names without counts
+func tagNames(tagList []TagCount) []string {
+
+	names := []string{}
+	for _, tagCount := range tagList {
+		names = append(names, tagCount.Tag)
+	}
+
+	return names
+}
+
+// displayTagResults runs a tag query and displays the results
+func displayTagResults(selectedTags []string, matchAll bool) {
+
+	startQuery("tags")
+	currentQuery["tags"] = strings.Join(selectedTags, tagQuerySeparator)
+
+	if matchAll {
+		currentQuery["tagMode"] = "all"
+
+	} else {
+		currentQuery["tagMode"] = "any"
+	}
+
+	currentRecipes, currentCount = getCurrentResults(0)
+
+	allPages := int(math.Ceil(float64(currentCount) / float64(config.resultsPerPage)))
+	currentPage = 1
+	displayResults(allPages, tagQueryTitle())
+}
+
+// currentTags returns the tags of the current tag query
+func currentTags() []string {
+	return queryTags(currentQuery)
+}
+
+func queryTags(query map[string]string) []string {
+
+	if len(query["tags"]) == 0 {
+		return []string{}
+	}
+
+	return strings.Split(query["tags"], tagQuerySeparator)
+}
+
+func tagQueryTitle() string {
+
+	if currentQuery["tagMode"] == "all" {
+		return strings.Join(currentTags(), " + ")
+	}
+
+	return strings.Join(currentTags(), " / ")
+}
+
+// reloadTags loads tag counts from the database and updates the navigation tree
+func reloadTags() {
+	tags = getTagCounts()
+	navTree.Refresh()
+}
+
+// tagNodes returns navigation tree node IDs of all tags, most used first. Like ingredient names, they are prefixed.
+func tagNodes() []widget.TreeNodeID {
+
+	nodes := []widget.TreeNodeID{}
+	for _, tagCount := range tags {
+		nodes = append(nodes, tagNodePrefix+tagCount.Tag)
+	}
+
+	return nodes
+}
+
+// tagCount returns the number of recipes with a tag
+func tagCount(tag string) int {
+
+	for _, tagCount := range tags {
+		if tagCount.Tag == tag {
+			return tagCount.Count
+		}
+	}
+
+	return 0
+}
+
+// isPopularTag tells if a tag is among the most used quarter of tags, which is shown in bold, like in a tag cloud
+func isPopularTag(tag string) bool {
+	return len(tags) != 0 && tagCount(tag) >= tags[len(tags)/4].Count
+}
+
+// createTagFilterBar creates controls for adding and removing tags of the current tag query and choosing AND/OR matching
+func createTagFilterBar() fyne.CanvasObject {
+
+	selectedTags := currentTags()
+	matchAll := currentQuery["tagMode"] == "all"
+
+	tagChips := container.NewHBox()
+
+	for _, tag := range selectedTags {
+		removedTag := tag
+		tagChips.Add(widget.NewButtonWithIcon(tag, theme.CancelIcon(), func() {
+
+			remainingTags := []string{}
+			for _, tag := range selectedTags {
+				if tag != removedTag {
+					remainingTags = append(remainingTags, tag)
+				}
+			}
+
+			// Without tags, the filter is cleared
+			if len(remainingTags) == 0 {
+				displayHomePage()
+				return
+			}
+
+			displayTagResults(remainingTags, matchAll)
+		}))
+	}
+
+	// Tags that can still be added to the filter
+	otherTags := []string{}
+	for _, tag := range tagNames(tags) {
+		if !slices.Contains(selectedTags, tag) {
+			otherTags = append(otherTags, tag)
+		}
+	}
+
+	addTagSelect := widget.NewSelect(otherTags, func(tag string) {
+		displayTagResults(append(selectedTags, tag), matchAll)
+	})
+	addTagSelect.PlaceHolder = tr("Add tag")
+
+	matchRadio := widget.NewRadioGroup([]string{tr("All tags"), tr("Any tag")}, nil)
+	matchRadio.Horizontal = true
+
+	if matchAll {
+		matchRadio.SetSelected(tr("All tags"))
+
+	} else {
+		matchRadio.SetSelected(tr("Any tag"))
+	}
+
+	matchRadio.OnChanged = func(selected string) {
+		if selected != "" {
+			displayTagResults(selectedTags, selected == tr("All tags"))
+		}
+	}
+
+	return container.NewBorder(nil, nil, nil, container.NewHBox(addTagSelect, matchRadio), container.NewHScroll(tagChips))
+}
+
+// createTagEditor creates an entry with tag suggestions and a list of removable tags.
+// The returned function gives the currently entered tags.
+func createTagEditor(initialTags []string) (fyne.CanvasObject, func() []string) {
+
+	recipeTags := append([]string{}, initialTags...)
+	tagChips := container.NewHBox()
+
+	var refreshChips func()
+
+	addTag := func(tag string) {
+
+		tag = normalizeTag(tag)
+
+		if len(tag) != 0 && !slices.Contains(recipeTags, tag) {
+			recipeTags = append(recipeTags, tag)
+			refreshChips()
+		}
+	}
+
+	refreshChips = func() {
+
+		tagChips.RemoveAll()
+
+		for _, tag := range recipeTags {
+			removedTag := tag
+			tagChips.Add(widget.NewButtonWithIcon(tag, theme.CancelIcon(), func() {
+				recipeTags = slices.DeleteFunc(recipeTags, func(t string) bool { return t == removedTag })
+				refreshChips()
+			}))
+		}
+
+		tagChips.Refresh()
+	}
+
+	tagEntry := newSuggestionEntry(func(text string) []Suggestion {
+
+		candidates := []Suggestion{}
+		for _, tag := range tagNames(tags) {
+			candidates = append(candidates, Suggestion{Text: tag, Kind: "Tag"})
+		}
+
+		return matchSuggestions(text, candidates)
+	})
+	tagEntry.SetPlaceHolder(tr("Add tag"))
+
+	tagEntry.OnSuggestionChosen = func(suggestion Suggestion) {
+		addTag(suggestion.Text)
+		tagEntry.SetText("")
+	}
+
+	tagEntry.OnSubmitted = func(text string) {
+		addTag(text)
+		tagEntry.SetText("")
+	}
+
+	addTagButton := &widget.Button{Icon: theme.ContentAddIcon(), OnTapped: func() { tagEntry.OnSubmitted(tagEntry.Text) }}
+
+	refreshChips()
+
+	editor := container.NewVBox(
+		container.NewBorder(nil, nil, nil, addTagButton, tagEntry),
+		container.NewHScroll(tagChips),
+	)
+
+	return editor, func() []string { return recipeTags }
+}
@@ -0,0 +1,74 @@
+package main
+
+// Unit systems ingredient quantities can be shown in
+const (
+	unitSystemOriginal = "original"
+	unitSystemMetric   = "metric"
+	unitSystemUS       = "us"
+)
+
+var unitSystemNames = map[string]string{
+	unitSystemOriginal: "As written",
+	unitSystemMetric:   "Metric",
+	unitSystemUS:       "US customary",
+}
+
+// Units converted when showing quantities in the other system. Spoons and pinches are used in both systems and are kept.
+var metricUnits = map[string]bool{
+	"g": true, "gram": true, "grams": true, "gr": true, "kg": true, "dag": true,
+	"ml": true, "cl": true, "dl": true, "l": true,
+}
+
+var usUnits = map[string]bool{
+	"oz": true, "lb": true, "cup": true, "skodelica": true,
+}
+
+// inUnitSystem returns the ingredient with its quantity converted to the given unit system
+func inUnitSystem(ingr Ingredient, system string) Ingredient {
+
+	unit := normalizeUnit(ingr.Unit)
+
+	if ingr.Quantity == 0 || (system == unitSystemMetric && !usUnits[unit]) || (system == unitSystemUS && !metricUnits[unit]) {
+		return ingr
+	}
+
+	amount, dimension, known := unitAmount(ingr.Quantity, unit)
+	if !known {
+		return ingr
+	}
+
+	switch {
+	case system == unitSystemMetric && dimension == "weight":
+		ingr.Quantity, ingr.Unit = largerUnit(amount, "g", "kg", weightUnits["kg"])
+
+	case system == unitSystemMetric && dimension == "volume":
+		ingr.Quantity, ingr.Unit = largerUnit(amount, "ml", "l", volumeUnits["l"])
+
+	case system == unitSystemUS && dimension == "weight":
+		ingr.Quantity, ingr.Unit = largerUnit(amount/weightUnits["oz"], "oz", "lb", weightUnits["lb"]/weightUnits["oz"])
+
+	// Small volumes are measured with spoons, larger ones in cups
+	case system == unitSystemUS && dimension == "volume" && amount >= volumeUnits["cup"]/4:
+		ingr.Quantity, ingr.Unit = amount/volumeUnits["cup"], "cup"
+
+	case system == unitSystemUS && dimension == "volume" && amount >= volumeUnits["tbsp"]:
+		ingr.Quantity, ingr.Unit = amount/volumeUnits["tbsp"], "tbsp"
+
+	case system == unitSystemUS && dimension == "volume":
+		ingr.Quantity, ingr.Unit = amount/volumeUnits["tsp"], "tsp"
+	}
+
+	ingr.Quantity = roundQuantity(ingr.Quantity)
+
+	return ingr
+}
+
+// largerUnit returns an amount in the larger unit once it reaches one of them
+func largerUnit(amount float64, smallUnit string, largeUnit string, smallPerLarge float64) (float64, string) {
+
+	if amount >= smallPerLarge {
+		return amount / smallPerLarge, largeUnit
+	}
+
+	return amount, smallUnit
+}
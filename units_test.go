@@ -0,0 +1,38 @@
+package main
+
+import "testing"
+
+func TestInUnitSystem(t *testing.T) {
+
+	tests := []struct {
+		system   string
+		quantity float64
+		unit     string
+		want     float64
+		wantUnit string
+	}{
+		{unitSystemOriginal, 2, "cup", 2, "cup"},
+		{unitSystemMetric, 2, "cup", 480, "ml"},
+		{unitSystemMetric, 5, "cup", 1.2, "l"},
+		{unitSystemMetric, 1, "lb", 453.6, "g"},
+		{unitSystemMetric, 3, "lb", 1.36, "kg"},
+		{unitSystemMetric, 500, "g", 500, "g"},
+		{unitSystemMetric, 1, "tsp", 1, "tsp"},
+		{unitSystemUS, 200, "g", 7.05, "oz"},
+		{unitSystemUS, 500, "g", 1.1, "lb"},
+		{unitSystemUS, 1, "kg", 2.2, "lb"},
+		{unitSystemUS, 250, "ml", 1.04, "cup"},
+		{unitSystemUS, 30, "ml", 2, "tbsp"},
+		{unitSystemUS, 10, "ml", 2, "tsp"},
+		{unitSystemUS, 0, "g", 0, "g"},
+	}
+
+	for _, test := range tests {
+
+		converted := inUnitSystem(Ingredient{Name: "test", Quantity: test.quantity, Unit: test.unit}, test.system)
+
+		if converted.Quantity != test.want || converted.Unit != test.wantUnit {
+			t.Errorf("inUnitSystem(%v %s, %s) = %v %s, want %v %s", test.quantity, test.unit, test.system, converted.Quantity, converted.Unit, test.want, test.wantUnit)
+		}
+	}
+}